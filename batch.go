@@ -0,0 +1,281 @@
+package fss2020
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// Options configures optional behavior for full-domain and batched
+// evaluation. The zero value evaluates serially.
+type Options struct {
+	// Parallelism is the number of subtrees evaluated concurrently by
+	// EvaluateAll. Values <= 1 evaluate serially.
+	Parallelism int
+}
+
+// dcfNodeState is a single point on a root-to-leaf path: the seed/t-bit pair
+// carried down the GGM tree, plus the group value accumulated so far.
+type dcfNodeState struct {
+	seed  []byte
+	tbit  byte
+	value *big.Int
+}
+
+// EvaluateAll walks the GGM tree once, reusing every intermediate PRG
+// expansion, to produce all 2^n outputs in O(2^n) work rather than the
+// O(n*2^n) a naive loop over Evaluate would cost. Result index i corresponds
+// to the domain point x = i - 2^{n-1}, the same mapping Evaluate uses.
+//
+// If opts is non-nil and opts.Parallelism > 1, the top levels of the tree
+// are expanded serially until there are at least that many independent
+// subtrees, which are then evaluated concurrently by a worker pool.
+func (d *DCFScheme) EvaluateAll(key *DCFKey, opts *Options) ([]*big.Int, error) {
+	n := len(key.CWs)
+	results := make([]*big.Int, 1<<n)
+
+	parallelism := 1
+	if opts != nil && opts.Parallelism > 1 {
+		parallelism = opts.Parallelism
+	}
+
+	splitDepth := 0
+	for splitDepth < n && (1<<splitDepth) < parallelism {
+		splitDepth++
+	}
+
+	type frontierNode struct {
+		state dcfNodeState
+		index int
+	}
+
+	frontier := []frontierNode{{
+		state: dcfNodeState{seed: key.Seed, tbit: byte(key.Party), value: new(big.Int)},
+		index: 0,
+	}}
+
+	for depth := 0; depth < splitDepth; depth++ {
+		next := make([]frontierNode, 0, len(frontier)*2)
+		for _, node := range frontier {
+			left, right, err := d.expandNodeBothBranches(key, depth, node.state)
+			if err != nil {
+				return nil, err
+			}
+
+			next = append(next, frontierNode{state: left, index: node.index << 1})
+			next = append(next, frontierNode{state: right, index: node.index<<1 | 1})
+		}
+
+		frontier = next
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	errs := make(chan error, len(frontier))
+
+	for _, node := range frontier {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(node frontierNode) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := d.evaluateAllWalk(key, splitDepth, node.state, node.index, results); err != nil {
+				errs <- err
+			}
+		}(node)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// evaluateAllWalk completes a DFS of the subtree rooted at (depth, state),
+// writing every leaf it reaches into results at the corresponding index.
+func (d *DCFScheme) evaluateAllWalk(key *DCFKey, depth int, state dcfNodeState, index int, results []*big.Int) error {
+	n := len(key.CWs)
+
+	if depth == n {
+		leaf, err := d.finalizeLeaf(key, state)
+		if err != nil {
+			return err
+		}
+
+		results[index] = leaf
+
+		return nil
+	}
+
+	left, right, err := d.expandNodeBothBranches(key, depth, state)
+	if err != nil {
+		return err
+	}
+
+	if err := d.evaluateAllWalk(key, depth+1, left, index<<1, results); err != nil {
+		return err
+	}
+
+	return d.evaluateAllWalk(key, depth+1, right, index<<1|1, results)
+}
+
+// EvaluateBatch evaluates key at every point in xs, sharing a single walk of
+// the GGM tree across all of them: xs is sorted so points that share a
+// prefix share the PRG expansions along it, then results are emitted back in
+// the original order of xs. Subtrees with no requested point are skipped
+// entirely, so this avoids both calling Evaluate once per point (which
+// redoes shared prefix expansions) and EvaluateAll's O(2^n) full walk when
+// len(xs) is much smaller than 2^n.
+func (d *DCFScheme) EvaluateBatch(key *DCFKey, xs []int) ([]*big.Int, error) {
+	n := len(key.CWs)
+	threshold := 1 << (n - 1)
+
+	type request struct {
+		mapped int
+		origin int
+	}
+
+	reqs := make([]request, len(xs))
+	for i, x := range xs {
+		if x >= threshold || x < -threshold {
+			return nil, fmt.Errorf("x (%d) must be within the range [-2^{n-1} (%d), 2^{n-1} (%d) - 1]", x, -threshold, threshold-1)
+		}
+
+		reqs[i] = request{mapped: x + threshold, origin: i}
+	}
+
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].mapped < reqs[j].mapped })
+
+	results := make([]*big.Int, len(xs))
+
+	var walk func(depth int, state dcfNodeState, group []request) error
+	walk = func(depth int, state dcfNodeState, group []request) error {
+		if len(group) == 0 {
+			return nil
+		}
+
+		if depth == n {
+			leaf, err := d.finalizeLeaf(key, state)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range group {
+				results[r.origin] = new(big.Int).Set(leaf)
+			}
+
+			return nil
+		}
+
+		left, right, err := d.expandNodeBothBranches(key, depth, state)
+		if err != nil {
+			return err
+		}
+
+		splitAt := sort.Search(len(group), func(i int) bool {
+			return (group[i].mapped>>(n-depth-1))&1 == 1
+		})
+
+		if err := walk(depth+1, left, group[:splitAt]); err != nil {
+			return err
+		}
+
+		return walk(depth+1, right, group[splitAt:])
+	}
+
+	initial := dcfNodeState{seed: key.Seed, tbit: byte(key.Party), value: new(big.Int)}
+	if err := walk(0, initial, reqs); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// expandNodeBothBranches expands the PRG node at state and applies the
+// correction word for this level to both children, returning the resulting
+// (seed, t-bit, accumulated value) state for each. It mirrors the per-branch
+// logic in Evaluate, but computes both branches at once since full-domain
+// and batched evaluation need to follow both.
+func (d *DCFScheme) expandNodeBothBranches(key *DCFKey, depth int, state dcfNodeState) (leftState, rightState dcfNodeState, err error) {
+	node, err := d.expandDCFNode(state.seed)
+	if err != nil {
+		return dcfNodeState{}, dcfNodeState{}, fmt.Errorf("expand dcf node: %w", err)
+	}
+
+	sL := make([]byte, len(node.Seeds[left]))
+	copy(sL, node.Seeds[left])
+	sR := make([]byte, len(node.Seeds[right]))
+	copy(sR, node.Seeds[right])
+	tL, tR := node.TBits[left], node.TBits[right]
+
+	cw := key.CWs[depth]
+	if state.tbit == 1 {
+		for j := range d.lambdaInBits / 8 {
+			sL[j] ^= cw.Seed[j]
+			sR[j] ^= cw.Seed[j]
+		}
+
+		tL ^= cw.TBits[left]
+		tR ^= cw.TBits[right]
+	}
+
+	vL, err := d.mapToGroupElement(node.Values[left])
+	if err != nil {
+		return dcfNodeState{}, dcfNodeState{}, fmt.Errorf("convert value: %w", err)
+	}
+
+	vR, err := d.mapToGroupElement(node.Values[right])
+	if err != nil {
+		return dcfNodeState{}, dcfNodeState{}, fmt.Errorf("convert value: %w", err)
+	}
+
+	if state.tbit == 1 {
+		vL.Add(vL, cw.Value)
+		vR.Add(vR, cw.Value)
+	}
+
+	if key.Party%2 == 1 {
+		vL.Neg(vL)
+		vR.Neg(vR)
+	}
+
+	leftValue := new(big.Int).Add(state.value, vL)
+	leftValue.Mod(leftValue, d.order())
+
+	rightValue := new(big.Int).Add(state.value, vR)
+	rightValue.Mod(rightValue, d.order())
+
+	leftState = dcfNodeState{seed: sL, tbit: tL, value: leftValue}
+	rightState = dcfNodeState{seed: sR, tbit: tR, value: rightValue}
+
+	return leftState, rightState, nil
+}
+
+// finalizeLeaf applies the final correction word to a root-to-leaf path's
+// accumulated state, the same computation as the tail of Evaluate.
+func (d *DCFScheme) finalizeLeaf(key *DCFKey, state dcfNodeState) (*big.Int, error) {
+	snConverted, err := d.mapToGroupElement(state.seed)
+	if err != nil {
+		return nil, fmt.Errorf("convert value: %w", err)
+	}
+
+	if state.tbit == 1 {
+		snConverted.Add(snConverted, key.FinalValue)
+	}
+
+	if key.Party%2 == 1 {
+		snConverted.Neg(snConverted)
+	}
+
+	value := new(big.Int).Add(state.value, snConverted)
+	value.Mod(value, d.order())
+
+	return value, nil
+}
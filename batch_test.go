@@ -0,0 +1,100 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestEvaluateAll(t *testing.T) {
+	const (
+		n      = 6
+		lambda = 128
+		alpha  = 10
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, key1, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	for _, parallelism := range []int{0, 1, 4} {
+		all0, err := dcfScheme.EvaluateAll(key0, &fss2020.Options{Parallelism: parallelism})
+		if err != nil {
+			t.Fatalf("EvaluateAll for key0 (parallelism=%d) failed: %v", parallelism, err)
+		}
+
+		all1, err := dcfScheme.EvaluateAll(key1, &fss2020.Options{Parallelism: parallelism})
+		if err != nil {
+			t.Fatalf("EvaluateAll for key1 (parallelism=%d) failed: %v", parallelism, err)
+		}
+
+		threshold := 1 << (n - 1)
+		for i := range all0 {
+			x := i - threshold
+
+			want, err := dcfScheme.Evaluate(key0, x)
+			if err != nil {
+				t.Fatalf("Evaluate for key0 at x=%d failed: %v", x, err)
+			}
+
+			if all0[i].Cmp(want) != 0 {
+				t.Errorf("EvaluateAll(key0)[%d] = %v, want %v", i, all0[i], want)
+			}
+
+			result := dcfScheme.Reconstruct(all0[i], all1[i])
+			wantSum := int64(0)
+			if x < alpha {
+				wantSum = beta
+			}
+
+			if result.Cmp(big.NewInt(wantSum)) != 0 {
+				t.Errorf("f0(%d) + f1(%d) = %v, want %v", x, x, result, wantSum)
+			}
+		}
+	}
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	xs := []int{15, -5, 10, 0, -128, 127, 5}
+
+	got, err := dcfScheme.EvaluateBatch(key0, xs)
+	if err != nil {
+		t.Fatalf("EvaluateBatch failed: %v", err)
+	}
+
+	if len(got) != len(xs) {
+		t.Fatalf("EvaluateBatch returned %d results, want %d", len(got), len(xs))
+	}
+
+	for i, x := range xs {
+		want, err := dcfScheme.Evaluate(key0, x)
+		if err != nil {
+			t.Fatalf("Evaluate at x=%d failed: %v", x, err)
+		}
+
+		if got[i].Cmp(want) != 0 {
+			t.Errorf("EvaluateBatch(...)[%d] (x=%d) = %v, want %v", i, x, got[i], want)
+		}
+	}
+}
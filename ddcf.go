@@ -12,20 +12,20 @@ type DDCFKey struct {
 }
 
 func (d *DCFScheme) GenerateDDCFKeys(n int, alpha int, beta0 int, beta1 int) (*DDCFKey, *DDCFKey, error) {
-	betaDiff := (beta0 - beta1) % int(d.groupOrder.Int64())
+	betaDiff := (beta0 - beta1) % int(d.order().Int64())
 	key0, key1, err := d.GenerateKeys(n, alpha, betaDiff)
 	if err != nil {
 		return nil, nil, fmt.Errorf("generate dcf keys: %w", err)
 	}
 
 	b1 := big.NewInt(int64(beta1))
-	s0, err := rand.Int(rand.Reader, d.groupOrder)
+	s0, err := rand.Int(rand.Reader, d.order())
 	if err != nil {
 		return nil, nil, fmt.Errorf("random generation error: %w", err)
 	}
 
 	s1 := new(big.Int).Sub(b1, s0)
-	s1.Mod(s1, d.groupOrder)
+	s1.Mod(s1, d.order())
 
 	ddcfKey0 := &DDCFKey{
 		DCFKey: key0,
@@ -46,7 +46,7 @@ func (d *DCFScheme) EvaluateDDCF(key *DDCFKey, x int) (*big.Int, error) {
 	}
 
 	y.Add(y, key.S)
-	y.Mod(y, d.groupOrder)
+	y.Mod(y, d.order())
 
 	return y, nil
 }
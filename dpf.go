@@ -0,0 +1,249 @@
+package fss2020
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// DPFScheme implements Distributed Point Functions (DPF), the equality-test
+// counterpart to DCF described in the same paper:
+// f_{Œ±,Œ≤}(x) = Œ≤ if x == Œ±, else 0.
+//
+// The tree-based construction mirrors DCFScheme almost exactly, except the
+// sum-along-path invariant that DCF maintains at every level only needs to
+// fire once, at the leaf, so there is no per-level v_{CW}. DPFScheme embeds
+// *DCFScheme to reuse its PRG node expansion, Convert_ùîæ, and Reconstruct.
+type DPFScheme struct {
+	*DCFScheme
+}
+
+// NewDPFScheme creates a DPFScheme over the power-of-two group
+// Z/groupOrderZ. For prime-order or elliptic-curve scalar groups, use
+// NewDPFSchemeWithGroup.
+func NewDPFScheme(lambdaInBits int, groupOrder *big.Int) *DPFScheme {
+	return &DPFScheme{NewDCFScheme(lambdaInBits, groupOrder)}
+}
+
+// NewDPFSchemeWithGroup creates a DPFScheme over an arbitrary Group, e.g. a
+// PrimeFieldGroup or EllipticScalarGroup.
+func NewDPFSchemeWithGroup(lambdaInBits int, group Group) *DPFScheme {
+	return &DPFScheme{NewDCFSchemeWithGroup(lambdaInBits, group)}
+}
+
+func (d *DPFScheme) GenerateKeys(n, alpha int, beta *big.Int) (key0 *DPFKey, key1 *DPFKey, err error) {
+	threshold := 1 << (n - 1)
+	if alpha >= threshold || alpha < -threshold {
+		return nil, nil, fmt.Errorf("alpha (%d) must be within the range [-2^{n-1} (%d), 2^{n-1} (%d) - 1]", alpha, -threshold, threshold-1)
+	}
+
+	// Map Œ± from [-2^{n-1}, 2^{n-1} - 1] to [0, 2^n - 1]
+	alpha += threshold
+
+	seeds := make([][][]byte, partyNum)
+	for i := range seeds {
+		seeds[i] = make([][]byte, n+1)
+
+		initialSeed := make([]byte, d.lambdaInBits/8)
+		if _, err := rand.Read(initialSeed); err != nil {
+			return nil, nil, fmt.Errorf("random generation error: %w", err)
+		}
+
+		seeds[i][0] = initialSeed
+	}
+
+	ts := make([][]byte, partyNum)
+	for i := range ts {
+		ts[i] = make([]byte, n+1)
+		ts[i][0] = byte(i)
+	}
+
+	correctionWords := make([]*DPFCorrectionWord, n)
+
+	for i := range n {
+		alphaBit := (alpha >> (n - i - 1)) & 1
+
+		isPartyActive := [partyNum]bool{
+			ts[0][i] == 1,
+			ts[1][i] == 1,
+		}
+
+		nodes := [partyNum]*ExpandedDCFNode{}
+		for party := range partyNum {
+			// s_{b,L} || t_{b,L} || s_{b,R} || t_{b,R} ‚Üê PRG(s_{b}[i])
+			node, err := d.expandDCFNode(seeds[party][i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("expand dpf node: %w", err)
+			}
+
+			nodes[party] = node
+		}
+
+		keep, lose := right, left
+		if alphaBit == 0 {
+			keep, lose = left, right
+		}
+
+		// s_{CW} = s_{0,Lose} ‚äï s_{1,Lose}
+		seedCW := make([]byte, d.lambdaInBits/8)
+		for j := range seedCW {
+			seedCW[j] = nodes[0].Seeds[lose][j] ^ nodes[1].Seeds[lose][j]
+		}
+
+		// t_{CW}[L] = t_{0,L} ‚äï t_{1,L} ‚äï Œ±_i ‚äï 1
+		// t_{CW}[R] = t_{0,R} ‚äï t_{1,R} ‚äï Œ±_i
+		tCWs := [2]byte{}
+		tCWs[left] = nodes[0].TBits[left] ^ nodes[1].TBits[left] ^ byte(alphaBit) ^ 1
+		tCWs[right] = nodes[0].TBits[right] ^ nodes[1].TBits[right] ^ byte(alphaBit)
+
+		correctionWords[i] = &DPFCorrectionWord{
+			Seed:  seedCW,
+			TBits: [2]byte{tCWs[left], tCWs[right]},
+		}
+
+		// s_{b}[i] = s_{b}[Keep] ‚äï t{b}[i] * s_{CW} for b ‚àà {0, 1}
+		for party := range partyNum {
+			seeds[party][i+1] = make([]byte, d.lambdaInBits/8)
+			for j := range seeds[party][i+1] {
+				seeds[party][i+1][j] = nodes[party].Seeds[keep][j]
+				if isPartyActive[party] {
+					seeds[party][i+1][j] ^= seedCW[j]
+				}
+			}
+		}
+
+		// t_{i+1} = t_{b}[Keep] ‚äï t_{b}[i] * t_{CW}[Keep] for b ‚àà {0, 1}
+		for party := range partyNum {
+			ts[party][i+1] = nodes[party].TBits[keep]
+			if isPartyActive[party] {
+				ts[party][i+1] ^= tCWs[keep]
+			}
+		}
+	}
+
+	// final correction value, encoding Œ≤ into the group:
+	// CW[n] = (-1)^{t_{1}[n]} * [Convert(s_{1}[n]) - Convert(s_{0}[n]) + Œ≤]
+	s0nConverted, err := d.mapToGroupElement(seeds[0][n])
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert value: %w", err)
+	}
+
+	s1nConverted, err := d.mapToGroupElement(seeds[1][n])
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert value: %w", err)
+	}
+
+	isParty1Active := ts[1][n] == 1
+
+	valueCW := new(big.Int)
+	valueCW.Sub(s1nConverted, s0nConverted)
+	valueCW.Add(valueCW, beta)
+
+	if isParty1Active {
+		valueCW.Neg(valueCW)
+	}
+
+	key0 = &DPFKey{
+		Party:      0,
+		Seed:       seeds[0][0],
+		CWs:        correctionWords,
+		FinalValue: new(big.Int).Set(valueCW),
+	}
+
+	correctionWordsForParty1 := make([]*DPFCorrectionWord, n)
+	for i, cw := range correctionWords {
+		correctionWordsForParty1[i] = &DPFCorrectionWord{
+			Seed:  append([]byte(nil), cw.Seed...),
+			TBits: cw.TBits,
+		}
+	}
+
+	key1 = &DPFKey{
+		Party:      1,
+		Seed:       seeds[1][0],
+		CWs:        correctionWordsForParty1,
+		FinalValue: new(big.Int).Set(valueCW),
+	}
+
+	return key0, key1, nil
+}
+
+func (d *DPFScheme) Evaluate(key *DPFKey, x int) (*big.Int, error) {
+	n := len(key.CWs)
+
+	threshold := 1 << (n - 1)
+	if x >= threshold || x < -threshold {
+		return nil, fmt.Errorf("x (%d) must be within the range [-2^{n-1} (%d), 2^{n-1} (%d) - 1]", x, -threshold, threshold-1)
+	}
+
+	// Map x from [-2^{n-1}, 2^{n-1} - 1] to [0, 2^n - 1]
+	x += threshold
+
+	tbits := make([]byte, n+1)
+	tbits[0] = byte(key.Party)
+	seeds := make([][]byte, n+1)
+	seeds[0] = key.Seed
+
+	for i := range n {
+		node, err := d.expandDCFNode(seeds[i])
+		if err != nil {
+			return nil, fmt.Errorf("expand dpf node: %w", err)
+		}
+
+		sL := make([]byte, len(node.Seeds[left]))
+		copy(sL, node.Seeds[left])
+		sR := make([]byte, len(node.Seeds[right]))
+		copy(sR, node.Seeds[right])
+		tL, tR := node.TBits[left], node.TBits[right]
+
+		if tbits[i] == 1 {
+			for j := range d.lambdaInBits / 8 {
+				sL[j] ^= key.CWs[i].Seed[j]
+				sR[j] ^= key.CWs[i].Seed[j]
+			}
+
+			tL ^= key.CWs[i].TBits[left]
+			tR ^= key.CWs[i].TBits[right]
+		}
+
+		xi := (x >> (n - i - 1)) & 1
+
+		if xi == 0 {
+			seeds[i+1] = sL
+			tbits[i+1] = tL
+		} else {
+			seeds[i+1] = sR
+			tbits[i+1] = tR
+		}
+	}
+
+	// V = (-1)^b * [Convert(s[n]) + t[n] * CW[n]]
+	snConverted, err := d.mapToGroupElement(seeds[n])
+	if err != nil {
+		return nil, fmt.Errorf("convert value: %w", err)
+	}
+
+	if tbits[n] == 1 {
+		snConverted.Add(snConverted, key.FinalValue)
+	}
+
+	if key.Party%2 == 1 {
+		snConverted.Neg(snConverted)
+	}
+
+	snConverted.Mod(snConverted, d.order())
+
+	return snConverted, nil
+}
+
+type DPFKey struct {
+	Party      int
+	Seed       []byte
+	CWs        []*DPFCorrectionWord
+	FinalValue *big.Int
+}
+
+type DPFCorrectionWord struct {
+	Seed  []byte
+	TBits [2]byte
+}
@@ -0,0 +1,94 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestDPFScheme(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	beta := big.NewInt(7)
+	dpfScheme := fss2020.NewDPFScheme(lambda, groupOrder)
+
+	testCases := []struct {
+		name  string
+		alpha int
+		x     int
+		want  *big.Int
+	}{
+		{
+			name:  "x == alpha, should be beta",
+			alpha: 10,
+			x:     10,
+			want:  beta,
+		},
+		{
+			name:  "x == alpha (<0), should be beta",
+			alpha: -10,
+			x:     -10,
+			want:  beta,
+		},
+		{
+			name:  "x < alpha, should be 0",
+			alpha: 10,
+			x:     5,
+			want:  big.NewInt(0),
+		},
+		{
+			name:  "x > alpha, should be 0",
+			alpha: 10,
+			x:     15,
+			want:  big.NewInt(0),
+		},
+		{
+			name:  "edge case: alpha = 0, x = 0",
+			alpha: 0,
+			x:     0,
+			want:  beta,
+		},
+		{
+			name:  "edge case: alpha max, x == alpha",
+			alpha: (1 << (n - 1)) - 1,
+			x:     (1 << (n - 1)) - 1,
+			want:  beta,
+		},
+		{
+			name:  "edge case: alpha max, x != alpha",
+			alpha: (1 << (n - 1)) - 1,
+			x:     0,
+			want:  big.NewInt(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key0, key1, err := dpfScheme.GenerateKeys(n, tc.alpha, beta)
+			if err != nil {
+				t.Fatalf("GenerateKeys failed: %v", err)
+			}
+
+			y0, err := dpfScheme.Evaluate(key0, tc.x)
+			if err != nil {
+				t.Fatalf("Evaluate for key0 failed: %v", err)
+			}
+
+			y1, err := dpfScheme.Evaluate(key1, tc.x)
+			if err != nil {
+				t.Fatalf("Evaluate for key1 failed: %v", err)
+			}
+
+			result := dpfScheme.Reconstruct(y0, y1)
+
+			if result.Cmp(tc.want) != 0 {
+				t.Errorf("f0(x) + f1(x) = %v, want %v", result, tc.want)
+			}
+		})
+	}
+}
@@ -20,17 +20,51 @@ const (
 
 type DCFScheme struct {
 	lambdaInBits int
-	groupOrder   *big.Int
+	group        Group
+	block        cipher.Block
 }
 
+// NewDCFScheme creates a DCFScheme over the power-of-two group Z/groupOrderZ,
+// the group this package originally supported. For prime-order or
+// elliptic-curve scalar groups, use NewDCFSchemeWithGroup.
 func NewDCFScheme(lambdaInBits int, groupOrder *big.Int) *DCFScheme {
 	return &DCFScheme{
 		lambdaInBits,
-		groupOrder,
+		NewPowerOfTwoGroup(groupOrder),
+		newFixedKeyBlock(),
 	}
 }
 
+// NewDCFSchemeWithGroup creates a DCFScheme over an arbitrary Group, e.g. a
+// PrimeFieldGroup or EllipticScalarGroup.
+func NewDCFSchemeWithGroup(lambdaInBits int, group Group) *DCFScheme {
+	return &DCFScheme{
+		lambdaInBits,
+		group,
+		newFixedKeyBlock(),
+	}
+}
+
+// order returns the modulus shares are reduced under.
+func (d *DCFScheme) order() *big.Int {
+	return d.group.Order()
+}
+
+// GenerateKeys builds a 2-party DCF key pair for f_{alpha,beta}. beta is an
+// int, like alpha and n -- this package's original comparison values all
+// lived comfortably in a machine word. For a beta that doesn't (e.g. a
+// Shamir share reduced mod a large prime-order group, as
+// ThresholdDCFScheme's Shamir path needs), use generateKeysBigInt instead.
 func (d *DCFScheme) GenerateKeys(n int, alpha int, beta int) (key0 *DCFKey, key1 *DCFKey, err error) {
+	return d.generateKeysBigInt(n, alpha, big.NewInt(int64(beta)))
+}
+
+// generateKeysBigInt is GenerateKeys with beta threaded through as a
+// *big.Int rather than round-tripped through int, so it isn't bounded by a
+// machine word. It's unexported because every caller outside this package
+// works with int-sized comparison values; ThresholdDCFScheme's Shamir path
+// is the one in-package caller that needs the wider range.
+func (d *DCFScheme) generateKeysBigInt(n int, alpha int, beta *big.Int) (key0 *DCFKey, key1 *DCFKey, err error) {
 	threshold := 1 << (n - 1)
 	if alpha >= threshold || alpha < -threshold {
 		return nil, nil, fmt.Errorf("alpha (%d) must be within the range [-2^{n-1} (%d), 2^{n-1} (%d) - 1]", alpha, -threshold, threshold-1)
@@ -113,7 +147,7 @@ func (d *DCFScheme) GenerateKeys(n int, alpha int, beta int) (key0 *DCFKey, key1
 		// if Lose == L:
 		// V_{CW} = V_{CW} + (-1)^{t_{1}[i]} * Œ≤
 		if lose == left {
-			betaCorrected := new(big.Int).SetInt64(int64(beta))
+			betaCorrected := new(big.Int).Set(beta)
 			if isPartyActive[1] {
 				betaCorrected.Neg(betaCorrected)
 			}
@@ -199,10 +233,12 @@ func (d *DCFScheme) GenerateKeys(n int, alpha int, beta int) (key0 *DCFKey, key1
 
 	// key_b = s_b[0] || CW[0] || ... || CW[n] for b ‚àà {0, 1}
 	key0 = &DCFKey{
-		Party:      0,
-		Seed:       seeds[0][0],
-		CWs:        correctionWords,
-		FinalValue: new(big.Int).Set(valueCW),
+		Party:        0,
+		Seed:         seeds[0][0],
+		CWs:          correctionWords,
+		FinalValue:   new(big.Int).Set(valueCW),
+		LambdaInBits: d.lambdaInBits,
+		GroupOrder:   d.order(),
 	}
 
 	correctionWordsForParty1 := make([]*DCFCorrectionWord, n)
@@ -215,10 +251,12 @@ func (d *DCFScheme) GenerateKeys(n int, alpha int, beta int) (key0 *DCFKey, key1
 	}
 
 	key1 = &DCFKey{
-		Party:      1,
-		Seed:       seeds[1][0],
-		CWs:        correctionWordsForParty1,
-		FinalValue: new(big.Int).Set(valueCW),
+		Party:        1,
+		Seed:         seeds[1][0],
+		CWs:          correctionWordsForParty1,
+		FinalValue:   new(big.Int).Set(valueCW),
+		LambdaInBits: d.lambdaInBits,
+		GroupOrder:   d.order(),
 	}
 
 	return key0, key1, nil
@@ -304,7 +342,7 @@ func (d *DCFScheme) Evaluate(key *DCFKey, x int) (*big.Int, error) {
 			}
 
 			value.Add(value, vConverted)
-			value.Mod(value, d.groupOrder)
+			value.Mod(value, d.order())
 
 			// s[i+1] = s_R
 			seeds[i+1] = sR
@@ -328,7 +366,7 @@ func (d *DCFScheme) Evaluate(key *DCFKey, x int) (*big.Int, error) {
 	}
 
 	value.Add(value, snConverted)
-	value.Mod(value, d.groupOrder)
+	value.Mod(value, d.order())
 
 	return value, nil
 }
@@ -337,7 +375,7 @@ func (d *DCFScheme) Reconstruct(ys ...*big.Int) *big.Int {
 	result := new(big.Int)
 	for _, y := range ys {
 		result.Add(result, y)
-		result.Mod(result, d.groupOrder)
+		result.Mod(result, d.order())
 	}
 
 	return result
@@ -357,17 +395,21 @@ func (d *DCFScheme) expandDCFNode(seed []byte) (*ExpandedDCFNode, error) {
 		return nil, fmt.Errorf("seed length must be equal to security parameter: (%d != %d)", len(seed), lambdaInBytes)
 	}
 
-	block, err := aes.NewCipher(seed)
-	if err != nil {
-		return nil, fmt.Errorf("aes cipher creation error: %w", err)
+	// prgExpand's Davies-Meyer construction feeds seed through a single
+	// fixed-key AES-128 block cipher, so it only ever reads the first
+	// aes.BlockSize (16) bytes of seed into each block. For lambdaInBits <=
+	// 128 that's the whole seed; for anything wider (192, 256) the extra
+	// bytes would be silently ignored -- every node's PRG output would
+	// depend on only the first 128 bits of its seed, quietly discarding the
+	// rest of the claimed security parameter. Reject that case explicitly
+	// rather than generate keys that are weaker than their LambdaInBits
+	// says.
+	if lambdaInBytes > aes.BlockSize {
+		return nil, fmt.Errorf("lambdaInBits (%d) > 128 is not supported: prgExpand's fixed-key AES-128 Davies-Meyer construction only consumes the first 128 bits of seed", d.lambdaInBits)
 	}
 
-	iv := make([]byte, aes.BlockSize)
-	stream := cipher.NewCTR(block, iv)
-
 	outputSize := (lambdaInBytes*2 + 1) * 2
-	output := make([]byte, outputSize)
-	stream.XORKeyStream(output, output)
+	output := prgExpand(d.block, seed, outputSize)
 
 	// output layout:
 	// 0      Œª     2Œª    2Œª+1   3Œª+1   4Œª+1   4Œª+2
@@ -390,42 +432,77 @@ func (d *DCFScheme) expandDCFNode(seed []byte) (*ExpandedDCFNode, error) {
 	return node, nil
 }
 
-// Convert_ùîæ: {0,1}^Œª ‚Üí ùîæ
-func (d DCFScheme) mapToGroupElement(input []byte) (*big.Int, error) {
-	if len(input) != int(d.lambdaInBits/8) {
-		return nil, fmt.Errorf("value length must be equal to security parameter (%d != %d)", len(input), d.lambdaInBits/8)
+// fixedPRGKey is a nothing-up-my-sleeve AES-128 key shared by every
+// DCFScheme: it is public, not secret, and exists only so expandDCFNode has
+// a block cipher to drive. Security comes from the secret seed fed through
+// prgExpand's Davies-Meyer construction, not from this key.
+var fixedPRGKey = []byte("fss2020 fixed-k!")
+
+// newFixedKeyBlock schedules fixedPRGKey once so DCFScheme can reuse the
+// resulting cipher.Block across every PRG expansion, instead of paying an
+// AES key schedule (aes.NewCipher) on every GGM-tree node the way this
+// package used to.
+func newFixedKeyBlock() cipher.Block {
+	block, err := aes.NewCipher(fixedPRGKey)
+	if err != nil {
+		// fixedPRGKey is a compile-time 16-byte constant, so the only
+		// failure mode aes.NewCipher has (invalid key length) can't happen.
+		panic(fmt.Sprintf("fss2020: fixed PRG key: %v", err))
 	}
 
-	if !isPowerOfTwo(d.groupOrder) {
-		return nil, fmt.Errorf("unsupported group order: must be a power of two")
-	}
+	return block
+}
 
-	k := d.groupOrder.BitLen() - 1
-	if k > d.lambdaInBits {
-		return nil, fmt.Errorf("unsupported group order: bit length must be less than or equal to security parameter (%d > %d)", k, d.lambdaInBits)
+// prgBatchSize is the chunk size prgExpand walks its block cipher calls in.
+// It changes neither the output nor the order blocks are computed in: every
+// block is encrypted, back to back and with no data dependence between the
+// calls, before the single Davies-Meyer XOR pass starts. Back-to-back,
+// data-independent calls to block.Encrypt let the CPU's AES-NI pipeline
+// overlap their latency instead of serializing on each result.
+const prgBatchSize = 8
+
+// prgExpand derives numBytes of pseudorandom output from seed using a
+// Davies-Meyer construction over the scheme's fixed-key block cipher:
+// block_i = AES_k(seed XOR i) XOR (seed XOR i), for as many blocks as
+// numBytes needs. Unlike the per-node AES key schedule this replaces, k is
+// fixed once per DCFScheme, so every call here is a plain block encryption.
+func prgExpand(block cipher.Block, seed []byte, numBytes int) []byte {
+	numBlocks := (numBytes + aes.BlockSize - 1) / aes.BlockSize
+	inputs := make([]byte, numBlocks*aes.BlockSize)
+	output := make([]byte, numBlocks*aes.BlockSize)
+
+	for i := range numBlocks {
+		in := inputs[i*aes.BlockSize : (i+1)*aes.BlockSize]
+		copy(in, seed)
+		in[aes.BlockSize-1] ^= byte(i)
 	}
 
-	// simply outputs the first k bits of the input
-	requiredBytes := (k + 7) / 8
-	if requiredBytes > len(input) {
-		return nil, fmt.Errorf("internal error: required bytes exceed input length (%d > %d)", requiredBytes, len(input))
+	for base := 0; base < numBlocks; base += prgBatchSize {
+		end := min(base+prgBatchSize, numBlocks)
+		for i := base; i < end; i++ {
+			block.Encrypt(output[i*aes.BlockSize:(i+1)*aes.BlockSize], inputs[i*aes.BlockSize:(i+1)*aes.BlockSize])
+		}
 	}
 
-	output := new(big.Int).SetBytes(input[:requiredBytes])
-	bitsToShift := uint(requiredBytes*8 - k) //nolint: gosec
-	output.Rsh(output, bitsToShift)
+	for i := range output {
+		output[i] ^= inputs[i]
+	}
 
-	return output, nil
+	return output[:numBytes]
 }
 
-func isPowerOfTwo(n *big.Int) bool {
-	if n.Sign() <= 0 {
-		return false
+// Convert_ùîæ: {0,1}^Œª ‚Üí ùîæ
+func (d DCFScheme) mapToGroupElement(input []byte) (*big.Int, error) {
+	if len(input) != int(d.lambdaInBits/8) {
+		return nil, fmt.Errorf("value length must be equal to security parameter (%d != %d)", len(input), d.lambdaInBits/8)
 	}
 
-	nMinus1 := new(big.Int).Sub(n, big.NewInt(1))
+	element, err := d.group.FromBytes(input)
+	if err != nil {
+		return nil, fmt.Errorf("convert to group element: %w", err)
+	}
 
-	return new(big.Int).And(n, nMinus1).Cmp(big.NewInt(0)) == 0
+	return asBigInt(element), nil
 }
 
 type DCFKey struct {
@@ -433,6 +510,12 @@ type DCFKey struct {
 	Seed       []byte
 	CWs        []*DCFCorrectionWord
 	FinalValue *big.Int
+
+	// LambdaInBits and GroupOrder mirror the generating DCFScheme's
+	// parameters so a serialized key is self-describing (see
+	// MarshalBinary); they aren't used by Evaluate itself.
+	LambdaInBits int
+	GroupOrder   *big.Int
 }
 
 type DCFCorrectionWord struct {
@@ -60,3 +60,56 @@ func BenchmarkEvaluate(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkEvaluateAll(b *testing.B) {
+	const (
+		n      = 16 // 16-bit integers
+		lambda = 128
+		alpha  = 12345
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		b.Fatalf("Setup failed: GenerateKeys failed: %v", err)
+	}
+
+	for b.Loop() {
+		_, err := dcfScheme.EvaluateAll(key0, nil)
+		if err != nil {
+			b.Fatalf("EvaluateAll failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkEvaluateBatch(b *testing.B) {
+	const (
+		n      = 16 // 16-bit integers
+		lambda = 128
+		alpha  = 12345
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		b.Fatalf("Setup failed: GenerateKeys failed: %v", err)
+	}
+
+	xs := make([]int, 1024)
+	for i := range xs {
+		xs[i] = i*64 - (1 << (n - 1))
+	}
+
+	for b.Loop() {
+		_, err := dcfScheme.EvaluateBatch(key0, xs)
+		if err != nil {
+			b.Fatalf("EvaluateBatch failed: %v", err)
+		}
+	}
+}
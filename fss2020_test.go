@@ -120,3 +120,18 @@ func TestDCFScheme(t *testing.T) {
 		})
 	}
 }
+
+func TestDCFSchemeRejectsLambdaAboveFixedKeyBlockSize(t *testing.T) {
+	const (
+		n     = 8
+		alpha = 10
+		beta  = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(256, groupOrder)
+
+	if _, _, err := dcfScheme.GenerateKeys(n, alpha, beta); err == nil {
+		t.Error("GenerateKeys with lambdaInBits=256 succeeded, want error (fixed-key PRG only consumes 128 bits of seed)")
+	}
+}
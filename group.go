@@ -0,0 +1,275 @@
+package fss2020
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Element is an opaque member of a Group. All arithmetic on it goes through
+// the Group that produced it.
+type Element interface {
+	// Bytes returns the element's canonical big-endian encoding, equivalent
+	// to the owning Group's Serialize.
+	Bytes() []byte
+}
+
+// Group abstracts the algebraic structure DCF/DPF/DDCF shares live in, so
+// the same GGM tree construction works over power-of-two groups (Z/2^kZ,
+// the scheme this package originally supported), arbitrary prime fields,
+// and elliptic-curve scalar fields used by pairing-based MPC stacks (e.g.
+// BLS12-381 in gnark-crypto).
+type Group interface {
+	Zero() Element
+	Add(a, b Element) Element
+	Neg(a Element) Element
+	Sub(a, b Element) Element
+	// FromBytes maps a Œª-bit PRG output into the group (Convert_ùîæ in the
+	// paper).
+	FromBytes(b []byte) (Element, error)
+	Random(r io.Reader) (Element, error)
+	Order() *big.Int
+	Serialize(e Element) []byte
+	Deserialize(b []byte) (Element, error)
+}
+
+// bigIntElement is the Element implementation shared by every Group in this
+// package: each one is, arithmetically, integers modulo its Order().
+type bigIntElement struct {
+	v *big.Int
+}
+
+func (e bigIntElement) Bytes() []byte {
+	return e.v.Bytes()
+}
+
+// asBigInt unwraps an Element produced by one of this package's Groups.
+// Elements are never meant to cross between Group implementations, so a
+// mismatched type indicates programmer error.
+func asBigInt(e Element) *big.Int {
+	v, ok := e.(bigIntElement)
+	if !ok {
+		panic(fmt.Sprintf("fss2020: element %T was not produced by a fss2020.Group", e))
+	}
+
+	return v.v
+}
+
+// PowerOfTwoGroup is Z/2^kZ, the group this package originally supported:
+// Convert_ùîæ simply keeps the first k bits of the PRG output.
+type PowerOfTwoGroup struct {
+	order *big.Int
+}
+
+func NewPowerOfTwoGroup(order *big.Int) *PowerOfTwoGroup {
+	return &PowerOfTwoGroup{order: order}
+}
+
+func (g *PowerOfTwoGroup) Zero() Element {
+	return bigIntElement{big.NewInt(0)}
+}
+
+func (g *PowerOfTwoGroup) Add(a, b Element) Element {
+	v := new(big.Int).Add(asBigInt(a), asBigInt(b))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+func (g *PowerOfTwoGroup) Sub(a, b Element) Element {
+	v := new(big.Int).Sub(asBigInt(a), asBigInt(b))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+func (g *PowerOfTwoGroup) Neg(a Element) Element {
+	v := new(big.Int).Neg(asBigInt(a))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+// FromBytes outputs the first k = log2(Order()) bits of input.
+func (g *PowerOfTwoGroup) FromBytes(input []byte) (Element, error) {
+	if !isPowerOfTwo(g.order) {
+		return nil, fmt.Errorf("unsupported group order: must be a power of two")
+	}
+
+	k := g.order.BitLen() - 1
+	if k > len(input)*8 {
+		return nil, fmt.Errorf("unsupported group order: bit length must be less than or equal to input length in bits (%d > %d)", k, len(input)*8)
+	}
+
+	requiredBytes := (k + 7) / 8
+	if requiredBytes > len(input) {
+		return nil, fmt.Errorf("internal error: required bytes exceed input length (%d > %d)", requiredBytes, len(input))
+	}
+
+	output := new(big.Int).SetBytes(input[:requiredBytes])
+	bitsToShift := uint(requiredBytes*8 - k) //nolint: gosec
+	output.Rsh(output, bitsToShift)
+
+	return bigIntElement{output}, nil
+}
+
+func (g *PowerOfTwoGroup) Random(r io.Reader) (Element, error) {
+	v, err := rand.Int(r, g.order)
+	if err != nil {
+		return nil, fmt.Errorf("random generation error: %w", err)
+	}
+
+	return bigIntElement{v}, nil
+}
+
+func (g *PowerOfTwoGroup) Order() *big.Int {
+	return new(big.Int).Set(g.order)
+}
+
+func (g *PowerOfTwoGroup) Serialize(e Element) []byte {
+	return asBigInt(e).Bytes()
+}
+
+func (g *PowerOfTwoGroup) Deserialize(b []byte) (Element, error) {
+	v := new(big.Int).SetBytes(b)
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}, nil
+}
+
+func isPowerOfTwo(n *big.Int) bool {
+	if n.Sign() <= 0 {
+		return false
+	}
+
+	nMinus1 := new(big.Int).Sub(n, big.NewInt(1))
+
+	return new(big.Int).And(n, nMinus1).Cmp(big.NewInt(0)) == 0
+}
+
+// maxHashToFieldAttempts bounds the rejection sampling FromBytes performs
+// for non-power-of-two groups. Each candidate is masked to Order()'s bit
+// length, so an attempt succeeds with probability at least 1/2 and this is
+// expected to succeed on the first or second attempt for any Order().
+const maxHashToFieldAttempts = 256
+
+// PrimeFieldGroup is Z/pZ for an arbitrary prime p, letting FSS shares be
+// composed with Shamir/BGW protocols that live natively in prime-order
+// groups. The caller is responsible for passing a prime order; this package
+// has no primality test.
+type PrimeFieldGroup struct {
+	order *big.Int
+}
+
+func NewPrimeFieldGroup(order *big.Int) *PrimeFieldGroup {
+	return &PrimeFieldGroup{order: new(big.Int).Set(order)}
+}
+
+func (g *PrimeFieldGroup) Zero() Element {
+	return bigIntElement{big.NewInt(0)}
+}
+
+func (g *PrimeFieldGroup) Add(a, b Element) Element {
+	v := new(big.Int).Add(asBigInt(a), asBigInt(b))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+func (g *PrimeFieldGroup) Sub(a, b Element) Element {
+	v := new(big.Int).Sub(asBigInt(a), asBigInt(b))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+func (g *PrimeFieldGroup) Neg(a Element) Element {
+	v := new(big.Int).Neg(asBigInt(a))
+	v.Mod(v, g.order)
+
+	return bigIntElement{v}
+}
+
+// FromBytes hashes input to a uniform element of Z/pZ via rejection
+// sampling (a simple hash_to_field), so that p not being a power of two
+// doesn't introduce modulo bias: plain `SetBytes(input) mod p` is biased
+// whenever p doesn't evenly divide 2^(8*len(input)). Each candidate is one
+// 256-bit SHA-256 digest, so for an order wider than 256 bits the result is
+// always below 2^256 rather than uniform over Z/pZ.
+//
+// Each candidate is masked down to order.BitLen() bits before the
+// rejection check. Comparing the full 256-bit SHA-256 digest against an
+// order much smaller than 2^256 (as most practical prime orders are)
+// would reject nearly every draw and exhaust maxHashToFieldAttempts;
+// masking to order's own bit length keeps the candidate in [0, 2^k) for
+// k = order.BitLen(), so it lands below order at least half the time
+// regardless of order's magnitude.
+func (g *PrimeFieldGroup) FromBytes(input []byte) (Element, error) {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(g.order.BitLen()))
+	mask.Sub(mask, big.NewInt(1))
+
+	for attempt := 0; attempt < maxHashToFieldAttempts; attempt++ {
+		h := sha256.New()
+		h.Write(input)
+		h.Write([]byte{byte(attempt)})
+		candidate := new(big.Int).SetBytes(h.Sum(nil))
+		candidate.And(candidate, mask)
+
+		if candidate.Cmp(g.order) < 0 {
+			return bigIntElement{candidate}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("hash_to_field: exceeded %d rejection sampling attempts", maxHashToFieldAttempts)
+}
+
+func (g *PrimeFieldGroup) Random(r io.Reader) (Element, error) {
+	v, err := rand.Int(r, g.order)
+	if err != nil {
+		return nil, fmt.Errorf("random generation error: %w", err)
+	}
+
+	return bigIntElement{v}, nil
+}
+
+func (g *PrimeFieldGroup) Order() *big.Int {
+	return new(big.Int).Set(g.order)
+}
+
+func (g *PrimeFieldGroup) Serialize(e Element) []byte {
+	return asBigInt(e).Bytes()
+}
+
+func (g *PrimeFieldGroup) Deserialize(b []byte) (Element, error) {
+	v := new(big.Int).SetBytes(b)
+	if v.Cmp(g.order) >= 0 {
+		return nil, fmt.Errorf("deserialize: value (%v) out of range for group order (%v)", v, g.order)
+	}
+
+	return bigIntElement{v}, nil
+}
+
+// EllipticScalarGroup is the scalar field Z/NZ of an elliptic curve (N being
+// the order of its base point), letting FSS shares be combined directly
+// with ECDSA/Schnorr-style scalars or pairing-based commitments defined over
+// the same curve. Arithmetically this is just a PrimeFieldGroup over N; the
+// curve is retained for callers that need it (e.g. to scalar-multiply the
+// base point by a reconstructed share).
+type EllipticScalarGroup struct {
+	*PrimeFieldGroup
+	curve elliptic.Curve
+}
+
+func NewEllipticScalarGroup(curve elliptic.Curve) *EllipticScalarGroup {
+	return &EllipticScalarGroup{
+		PrimeFieldGroup: NewPrimeFieldGroup(curve.Params().N),
+		curve:           curve,
+	}
+}
+
+func (g *EllipticScalarGroup) Curve() elliptic.Curve {
+	return g.curve
+}
@@ -0,0 +1,78 @@
+package fss2020_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestDCFSchemeWithGroup(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 1
+	)
+
+	// A 16-bit prime close to 2^16, chosen so Evaluate's groupOrder-sized
+	// inputs still fit comfortably inside it.
+	primeOrder := big.NewInt(65521)
+
+	testCases := []struct {
+		name  string
+		group fss2020.Group
+	}{
+		{name: "PrimeFieldGroup", group: fss2020.NewPrimeFieldGroup(primeOrder)},
+		{name: "EllipticScalarGroup/P256", group: fss2020.NewEllipticScalarGroup(elliptic.P256())},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dcfScheme := fss2020.NewDCFSchemeWithGroup(lambda, tc.group)
+
+			key0, key1, err := dcfScheme.GenerateKeys(n, alpha, beta)
+			if err != nil {
+				t.Fatalf("GenerateKeys failed: %v", err)
+			}
+
+			for x, want := range map[int]int64{5: beta, 10: 0, 15: 0} {
+				y0, err := dcfScheme.Evaluate(key0, x)
+				if err != nil {
+					t.Fatalf("Evaluate for key0 at x=%d failed: %v", x, err)
+				}
+
+				y1, err := dcfScheme.Evaluate(key1, x)
+				if err != nil {
+					t.Fatalf("Evaluate for key1 at x=%d failed: %v", x, err)
+				}
+
+				result := dcfScheme.Reconstruct(y0, y1)
+				if result.Cmp(big.NewInt(want)) != 0 {
+					t.Errorf("f0(%d) + f1(%d) = %v, want %v", x, x, result, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrimeFieldGroupFromBytesInRange(t *testing.T) {
+	order := big.NewInt(65521)
+	group := fss2020.NewPrimeFieldGroup(order)
+
+	for _, input := range [][]byte{
+		make([]byte, 16),
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	} {
+		element, err := group.FromBytes(input)
+		if err != nil {
+			t.Fatalf("FromBytes failed: %v", err)
+		}
+
+		v := new(big.Int).SetBytes(group.Serialize(element))
+		if v.Cmp(order) >= 0 {
+			t.Errorf("FromBytes(%x) = %v, want < order (%v)", input, v, order)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package fss2020
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// keyMagic identifies this package's key wire format; keyFormatVersion is
+// bumped whenever the layout below changes incompatibly, so a key generated
+// by one build can be validated (and rejected cleanly) by another.
+const (
+	keyMagic         uint32 = 0x46535332 // "FSS2"
+	keyFormatVersion uint8  = 1
+)
+
+// MarshalBinary encodes cw as: seedLen || seed || valueLen || value || t_L || t_R.
+//
+// cw.Value is only meaningful mod groupOrder (GenerateKeys routinely
+// produces negative values), so it's reduced to its canonical
+// non-negative residue before writing; Bytes() alone would drop the
+// sign and corrupt the round trip.
+func (cw *DCFCorrectionWord) MarshalBinary(groupOrder *big.Int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := writeFramed(&buf, cw.Seed); err != nil {
+		return nil, fmt.Errorf("write seed: %w", err)
+	}
+
+	if err := writeFramed(&buf, canonicalBytes(cw.Value, groupOrder)); err != nil {
+		return nil, fmt.Errorf("write value: %w", err)
+	}
+
+	buf.WriteByte(cw.TBits[0])
+	buf.WriteByte(cw.TBits[1])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into cw.
+func (cw *DCFCorrectionWord) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	seed, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read seed: %w", err)
+	}
+
+	valueBytes, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read value: %w", err)
+	}
+
+	tBits := make([]byte, 2)
+	if _, err := io.ReadFull(r, tBits); err != nil {
+		return fmt.Errorf("read t-bits: %w", err)
+	}
+
+	cw.Seed = seed
+	cw.Value = new(big.Int).SetBytes(valueBytes)
+	cw.TBits = [2]byte{tBits[0], tBits[1]}
+
+	return nil
+}
+
+// MarshalBinary encodes k with a versioned header so the key is
+// self-describing on the wire:
+// magic || version || lambdaInBits || n || groupOrderLen || groupOrder ||
+// party || seedLen || seed || CW_0 || ... || CW_{n-1} || finalValue.
+func (k *DCFKey) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := binary.Write(&buf, binary.BigEndian, keyMagic); err != nil {
+		return nil, fmt.Errorf("write magic: %w", err)
+	}
+
+	buf.WriteByte(keyFormatVersion)
+
+	if err := binary.Write(&buf, binary.BigEndian, uint32(k.LambdaInBits)); err != nil {
+		return nil, fmt.Errorf("write lambdaInBits: %w", err)
+	}
+
+	if err := binary.Write(&buf, binary.BigEndian, uint32(len(k.CWs))); err != nil {
+		return nil, fmt.Errorf("write n: %w", err)
+	}
+
+	if err := writeFramed(&buf, groupOrderBytes(k.GroupOrder)); err != nil {
+		return nil, fmt.Errorf("write group order: %w", err)
+	}
+
+	buf.WriteByte(byte(k.Party))
+
+	if err := writeFramed(&buf, k.Seed); err != nil {
+		return nil, fmt.Errorf("write seed: %w", err)
+	}
+
+	for i, cw := range k.CWs {
+		cwBytes, err := cw.MarshalBinary(k.GroupOrder)
+		if err != nil {
+			return nil, fmt.Errorf("marshal correction word %d: %w", i, err)
+		}
+
+		if err := writeFramed(&buf, cwBytes); err != nil {
+			return nil, fmt.Errorf("write correction word %d: %w", i, err)
+		}
+	}
+
+	if err := writeFramed(&buf, canonicalBytes(k.FinalValue, k.GroupOrder)); err != nil {
+		return nil, fmt.Errorf("write final value: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into k, rejecting
+// data with an unrecognized magic or an unsupported format version.
+func (k *DCFKey) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	var magic uint32
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil {
+		return fmt.Errorf("read magic: %w", err)
+	}
+
+	if magic != keyMagic {
+		return fmt.Errorf("unrecognized key format (magic %#x)", magic)
+	}
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("read version: %w", err)
+	}
+
+	if version != keyFormatVersion {
+		return fmt.Errorf("unsupported key format version %d (want %d)", version, keyFormatVersion)
+	}
+
+	var lambdaInBits, numCWs uint32
+	if err := binary.Read(r, binary.BigEndian, &lambdaInBits); err != nil {
+		return fmt.Errorf("read lambdaInBits: %w", err)
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &numCWs); err != nil {
+		return fmt.Errorf("read n: %w", err)
+	}
+
+	groupOrder, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read group order: %w", err)
+	}
+
+	party, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("read party: %w", err)
+	}
+
+	seed, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read seed: %w", err)
+	}
+
+	cws := make([]*DCFCorrectionWord, numCWs)
+	for i := range cws {
+		cwBytes, err := readFramed(r)
+		if err != nil {
+			return fmt.Errorf("read correction word %d: %w", i, err)
+		}
+
+		cw := &DCFCorrectionWord{}
+		if err := cw.UnmarshalBinary(cwBytes); err != nil {
+			return fmt.Errorf("unmarshal correction word %d: %w", i, err)
+		}
+
+		cws[i] = cw
+	}
+
+	finalValue, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read final value: %w", err)
+	}
+
+	k.Party = int(party)
+	k.Seed = seed
+	k.CWs = cws
+	k.FinalValue = new(big.Int).SetBytes(finalValue)
+	k.LambdaInBits = int(lambdaInBits)
+	k.GroupOrder = new(big.Int).SetBytes(groupOrder)
+
+	return nil
+}
+
+// MarshalBinary encodes k as its embedded DCFKey followed by the DDCF mask S.
+func (k *DDCFKey) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	dcfBytes, err := k.DCFKey.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal dcf key: %w", err)
+	}
+
+	if err := writeFramed(&buf, dcfBytes); err != nil {
+		return nil, fmt.Errorf("write dcf key: %w", err)
+	}
+
+	if err := writeFramed(&buf, canonicalBytes(k.S, k.GroupOrder)); err != nil {
+		return nil, fmt.Errorf("write s: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into k.
+func (k *DDCFKey) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	dcfBytes, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read dcf key: %w", err)
+	}
+
+	dcfKey := &DCFKey{}
+	if err := dcfKey.UnmarshalBinary(dcfBytes); err != nil {
+		return fmt.Errorf("unmarshal dcf key: %w", err)
+	}
+
+	sBytes, err := readFramed(r)
+	if err != nil {
+		return fmt.Errorf("read s: %w", err)
+	}
+
+	k.DCFKey = dcfKey
+	k.S = new(big.Int).SetBytes(sBytes)
+
+	return nil
+}
+
+// groupOrderBytes treats a nil *big.Int (e.g. a zero-value key) as zero
+// rather than panicking.
+func groupOrderBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+
+	return v.Bytes()
+}
+
+// canonicalBytes encodes a group element v as the big-endian bytes of its
+// canonical non-negative residue mod groupOrder. GenerateKeys' correction
+// words and final values are only meaningful mod groupOrder and are
+// routinely negative; big.Int.Bytes() encodes magnitude only and drops
+// the sign, so reducing first (rather than calling v.Bytes() directly)
+// is what makes the round trip value-preserving.
+func canonicalBytes(v, groupOrder *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+
+	if groupOrder == nil {
+		return v.Bytes()
+	}
+
+	return new(big.Int).Mod(v, groupOrder).Bytes()
+}
+
+func writeFramed(buf *bytes.Buffer, b []byte) error {
+	if err := binary.Write(buf, binary.BigEndian, uint32(len(b))); err != nil {
+		return err
+	}
+
+	_, err := buf.Write(b)
+
+	return err
+}
+
+func readFramed(r *bytes.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
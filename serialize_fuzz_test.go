@@ -0,0 +1,57 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+// FuzzDCFKeyRoundTrip generates keys for fuzzer-supplied alpha/beta/x and
+// checks that Evaluate returns identical results before and after a
+// Marshal/Unmarshal round trip.
+func FuzzDCFKeyRoundTrip(f *testing.F) {
+	f.Add(5, 1, 5)
+	f.Add(0, 1, 0)
+	f.Add(-100, 3, 100)
+	f.Add(1<<15-1, 1, -(1 << 15))
+
+	const (
+		n      = 16
+		lambda = 128
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), n)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	f.Fuzz(func(t *testing.T, alpha, beta, x int) {
+		key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+		if err != nil {
+			t.Skip()
+		}
+
+		want, err := dcfScheme.Evaluate(key0, x)
+		if err != nil {
+			t.Skip()
+		}
+
+		data, err := key0.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+
+		got := &fss2020.DCFKey{}
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+
+		roundTripped, err := dcfScheme.Evaluate(got, x)
+		if err != nil {
+			t.Fatalf("Evaluate(round-tripped key, %d) failed: %v", x, err)
+		}
+
+		if roundTripped.Cmp(want) != 0 {
+			t.Errorf("Evaluate(round-tripped key, %d) = %v, want %v", x, roundTripped, want)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestDCFKeyRoundTrip(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	data, err := key0.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	got := &fss2020.DCFKey{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+
+	for _, x := range []int{-5, 0, 5, 10, 15} {
+		want, err := dcfScheme.Evaluate(key0, x)
+		if err != nil {
+			t.Fatalf("Evaluate(key0, %d) failed: %v", x, err)
+		}
+
+		roundTripped, err := dcfScheme.Evaluate(got, x)
+		if err != nil {
+			t.Fatalf("Evaluate(got, %d) failed: %v", x, err)
+		}
+
+		if roundTripped.Cmp(want) != 0 {
+			t.Errorf("Evaluate(round-tripped key, %d) = %v, want %v", x, roundTripped, want)
+		}
+	}
+}
+
+func TestDCFKeyUnmarshalRejectsBadMagic(t *testing.T) {
+	data := []byte{0, 0, 0, 0, 1, 0, 0, 0, 0}
+
+	got := &fss2020.DCFKey{}
+	if err := got.UnmarshalBinary(data); err == nil {
+		t.Fatal("UnmarshalBinary should reject data with an unrecognized magic")
+	}
+}
+
+func TestDDCFKeyRoundTrip(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateDDCFKeys(n, alpha, 7, 3)
+	if err != nil {
+		t.Fatalf("GenerateDDCFKeys failed: %v", err)
+	}
+
+	data, err := key0.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	got := &fss2020.DDCFKey{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+
+	for _, x := range []int{-5, 0, 5, 10, 15} {
+		want, err := dcfScheme.EvaluateDDCF(key0, x)
+		if err != nil {
+			t.Fatalf("EvaluateDDCF(key0, %d) failed: %v", x, err)
+		}
+
+		roundTripped, err := dcfScheme.EvaluateDDCF(got, x)
+		if err != nil {
+			t.Fatalf("EvaluateDDCF(got, %d) failed: %v", x, err)
+		}
+
+		if roundTripped.Cmp(want) != 0 {
+			t.Errorf("EvaluateDDCF(round-tripped key, %d) = %v, want %v", x, roundTripped, want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package fss2020
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// defaultSplineLambdaInBits is the PRG security parameter used by the
+// ready-to-use gates (NewReLUGate, NewSigmoidGate), matching the lambda used
+// throughout the DCF/DDCF benchmarks and tests.
+const defaultSplineLambdaInBits = 128
+
+// Polynomial is a single polynomial piece of a spline, with coefficients
+// listed from the highest degree term to the constant term. Each coefficient
+// is fixed-point represented with the owning SplineScheme's precision
+// fractional bits, the same convention as the x passed to Evaluate.
+type Polynomial struct {
+	Coefficients []*big.Int
+}
+
+// SplineScheme implements FSS gates for piecewise-polynomial (spline)
+// functions, the headline application of the paper this package implements:
+// approximating non-linear functions (ReLU, sigmoid, tanh, division, sqrt,
+// ...) for fixed-point secure computation.
+//
+// Given breakpoints a_0 < a_1 < ... < a_{m-1} and m+1 polynomials
+// P_0, ..., P_m, a SplineKey evaluates to P_i(x) for the interval i that x
+// falls into (x < a_0 for i=0, a_{i-1} <= x < a_i for 0<i<m, x >= a_{m-1}
+// for i=m).
+//
+// Interval membership is a DDCF-based containment gadget: for each
+// breakpoint a_i, a DDCF key shares the step function 1[x<a_i]. The
+// difference of two adjacent steps is a share of 1 inside the interval and 0
+// outside, which is multiplied by the (public) polynomial for that interval
+// and summed across all intervals.
+type SplineScheme struct {
+	dcfScheme *DCFScheme
+	n         int
+	precision int
+}
+
+// NewSplineScheme creates a SplineScheme over n-bit domain values with
+// `precision` fractional bits of fixed-point precision, reusing groupOrder
+// as the power-of-two DDCF group.
+func NewSplineScheme(n, precision int, groupOrder *big.Int) *SplineScheme {
+	return NewSplineSchemeWithGroup(n, precision, NewPowerOfTwoGroup(groupOrder))
+}
+
+// NewSplineSchemeWithGroup creates a SplineScheme over an arbitrary Group,
+// e.g. a PrimeFieldGroup or EllipticScalarGroup.
+func NewSplineSchemeWithGroup(n, precision int, group Group) *SplineScheme {
+	return &SplineScheme{
+		dcfScheme: NewDCFSchemeWithGroup(defaultSplineLambdaInBits, group),
+		n:         n,
+		precision: precision,
+	}
+}
+
+type SplineKey struct {
+	Party       int
+	Boundaries  []*DDCFKey
+	Polynomials []Polynomial
+}
+
+// GenerateKeys builds a spline key pair for len(breakpoints)+1 intervals.
+// len(polynomials) must equal len(breakpoints)+1.
+func (s *SplineScheme) GenerateKeys(breakpoints []int, polynomials []Polynomial) (key0 *SplineKey, key1 *SplineKey, err error) {
+	if len(polynomials) != len(breakpoints)+1 {
+		return nil, nil, fmt.Errorf("spline: need %d polynomials for %d breakpoints, got %d", len(breakpoints)+1, len(breakpoints), len(polynomials))
+	}
+
+	boundaries0 := make([]*DDCFKey, len(breakpoints))
+	boundaries1 := make([]*DDCFKey, len(breakpoints))
+	for i, a := range breakpoints {
+		// step function: 1 if x < a, 0 otherwise
+		b0, b1, err := s.dcfScheme.GenerateDDCFKeys(s.n, a, 1, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate boundary %d: %w", i, err)
+		}
+
+		boundaries0[i] = b0
+		boundaries1[i] = b1
+	}
+
+	key0 = &SplineKey{Party: 0, Boundaries: boundaries0, Polynomials: polynomials}
+	key1 = &SplineKey{Party: 1, Boundaries: boundaries1, Polynomials: polynomials}
+
+	return key0, key1, nil
+}
+
+// Evaluate returns this party's share of P_i(x), where i is the interval x
+// falls into. The two parties' shares sum to P_i(x) mod the group order.
+func (s *SplineScheme) Evaluate(key *SplineKey, x int) (*big.Int, error) {
+	m := len(key.Boundaries)
+
+	steps := make([]*big.Int, m)
+	for i, boundary := range key.Boundaries {
+		step, err := s.dcfScheme.EvaluateDDCF(boundary, x)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate boundary %d: %w", i, err)
+		}
+
+		steps[i] = step
+	}
+
+	result := new(big.Int)
+	for i, poly := range key.Polynomials {
+		var indicator *big.Int
+		switch {
+		case m == 0:
+			indicator = s.constantShare(key.Party)
+		case i == 0:
+			indicator = steps[0]
+		case i == m:
+			indicator = new(big.Int).Sub(s.constantShare(key.Party), steps[m-1])
+		default:
+			indicator = new(big.Int).Sub(steps[i], steps[i-1])
+		}
+
+		contribution := new(big.Int).Mul(indicator, s.evalPolynomial(poly, x))
+		result.Add(result, contribution)
+	}
+
+	result.Mod(result, s.dcfScheme.order())
+
+	return result, nil
+}
+
+// constantShare additively shares the constant 1: party 0 holds 1, party 1
+// holds 0, so the two parties' shares always reconstruct to 1.
+func (s *SplineScheme) constantShare(party int) *big.Int {
+	if party == 0 {
+		return big.NewInt(1)
+	}
+
+	return new(big.Int)
+}
+
+// evalPolynomial evaluates poly at the fixed-point value x via Horner's
+// method, rescaling by precision after every multiplication so intermediate
+// products stay in the same Q(precision) format as the coefficients.
+func (s *SplineScheme) evalPolynomial(poly Polynomial, x int) *big.Int {
+	acc := new(big.Int)
+	bigX := big.NewInt(int64(x))
+
+	for _, c := range poly.Coefficients {
+		acc.Mul(acc, bigX)
+		acc.Rsh(acc, uint(s.precision)) //nolint: gosec
+		acc.Add(acc, c)
+	}
+
+	return acc
+}
+
+// NewReLUGate builds a ready-to-use spline gate for ReLU(x) = max(x, 0) over
+// an n-bit domain with the given fixed-point precision.
+func NewReLUGate(n, precision int) (scheme *SplineScheme, key0 *SplineKey, key1 *SplineKey, err error) {
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), uint(n)) //nolint: gosec
+	scheme = NewSplineScheme(n, precision, groupOrder)
+
+	scale := new(big.Int).Lsh(big.NewInt(1), uint(precision)) //nolint: gosec
+	polynomials := []Polynomial{
+		{Coefficients: []*big.Int{big.NewInt(0)}},
+		{Coefficients: []*big.Int{scale, big.NewInt(0)}},
+	}
+
+	key0, key1, err = scheme.GenerateKeys([]int{0}, polynomials)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("generate relu gate: %w", err)
+	}
+
+	return scheme, key0, key1, nil
+}
+
+// sigmoidRampLo and sigmoidRampHi bound the region NewSigmoidGate
+// approximates with a piecewise-linear ramp; outside this range sigmoid is
+// flattened to its 0/1 asymptotes, which is off by at most sigmoid(-4)
+// (about 0.018), reached at the ramp's ends.
+const (
+	sigmoidRampLo = -4.0
+	sigmoidRampHi = 4.0
+)
+
+// NewSigmoidGate builds a ready-to-use spline gate approximating the
+// logistic sigmoid over an n-bit domain with the given fixed-point
+// precision, as a piecewise-linear ramp of `degree` equal-width segments
+// between sigmoidRampLo and sigmoidRampHi (flat 0/1 outside that range).
+func NewSigmoidGate(n, precision, degree int) (scheme *SplineScheme, key0 *SplineKey, key1 *SplineKey, err error) {
+	if degree < 1 {
+		return nil, nil, nil, fmt.Errorf("sigmoid gate: degree (%d) must be >= 1", degree)
+	}
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), uint(n)) //nolint: gosec
+	scheme = NewSplineScheme(n, precision, groupOrder)
+	scale := math.Pow(2, float64(precision))
+	step := (sigmoidRampHi - sigmoidRampLo) / float64(degree)
+
+	breakpoints := make([]int, 0, degree+1)
+	polynomials := make([]Polynomial, 0, degree+2)
+
+	polynomials = append(polynomials, Polynomial{Coefficients: []*big.Int{big.NewInt(0)}})
+
+	for i := range degree {
+		lo := sigmoidRampLo + float64(i)*step
+		hi := lo + step
+		breakpoints = append(breakpoints, int(math.Round(lo*scale)))
+
+		sigLo, sigHi := sigmoid(lo), sigmoid(hi)
+		slope := (sigHi - sigLo) / (hi - lo)
+		intercept := sigLo - slope*lo
+
+		polynomials = append(polynomials, Polynomial{
+			Coefficients: []*big.Int{
+				big.NewInt(int64(math.Round(slope * scale))),
+				big.NewInt(int64(math.Round(intercept * scale))),
+			},
+		})
+	}
+
+	breakpoints = append(breakpoints, int(math.Round(sigmoidRampHi*scale)))
+	polynomials = append(polynomials, Polynomial{Coefficients: []*big.Int{big.NewInt(int64(scale))}})
+
+	key0, key1, err = scheme.GenerateKeys(breakpoints, polynomials)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("generate sigmoid gate: %w", err)
+	}
+
+	return scheme, key0, key1, nil
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
@@ -0,0 +1,56 @@
+package fss2020_test
+
+import (
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func BenchmarkReLUGate16(b *testing.B) {
+	benchmarkReLUGate(b, 16)
+}
+
+func BenchmarkReLUGate32(b *testing.B) {
+	benchmarkReLUGate(b, 32)
+}
+
+func benchmarkReLUGate(b *testing.B, n int) {
+	const precision = 8
+
+	scheme, key0, _, err := fss2020.NewReLUGate(n, precision)
+	if err != nil {
+		b.Fatalf("NewReLUGate failed: %v", err)
+	}
+
+	for b.Loop() {
+		if _, err := scheme.Evaluate(key0, 1<<(precision+1)); err != nil {
+			b.Fatalf("Evaluate failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkSigmoidGate16(b *testing.B) {
+	benchmarkSigmoidGate(b, 16)
+}
+
+func BenchmarkSigmoidGate32(b *testing.B) {
+	benchmarkSigmoidGate(b, 32)
+}
+
+func benchmarkSigmoidGate(b *testing.B, n int) {
+	const (
+		precision = 8
+		degree    = 8
+	)
+
+	scheme, key0, _, err := fss2020.NewSigmoidGate(n, precision, degree)
+	if err != nil {
+		b.Fatalf("NewSigmoidGate failed: %v", err)
+	}
+
+	for b.Loop() {
+		if _, err := scheme.Evaluate(key0, 1<<(precision-1)); err != nil {
+			b.Fatalf("Evaluate failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package fss2020_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestSplineSchemeReLU(t *testing.T) {
+	const (
+		n         = 16
+		precision = 8
+	)
+
+	scheme, key0, key1, err := fss2020.NewReLUGate(n, precision)
+	if err != nil {
+		t.Fatalf("NewReLUGate failed: %v", err)
+	}
+
+	scale := 1 << precision
+
+	testCases := []struct {
+		name string
+		x    float64
+	}{
+		{name: "negative input", x: -3.5},
+		{name: "zero input", x: 0},
+		{name: "positive input", x: 3.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x := int(math.Round(tc.x * float64(scale)))
+
+			y0, err := scheme.Evaluate(key0, x)
+			if err != nil {
+				t.Fatalf("Evaluate for key0 failed: %v", err)
+			}
+
+			y1, err := scheme.Evaluate(key1, x)
+			if err != nil {
+				t.Fatalf("Evaluate for key1 failed: %v", err)
+			}
+
+			result := new(big.Int).Add(y0, y1)
+			result.Mod(result, new(big.Int).Lsh(big.NewInt(1), n))
+
+			want := math.Max(tc.x, 0)
+			got := float64(result.Int64()) / float64(scale)
+
+			if math.Abs(got-want) > 1.0/float64(scale) {
+				t.Errorf("ReLU(%v) = %v, want %v", tc.x, got, want)
+			}
+		})
+	}
+}
+
+func TestSplineSchemeSigmoid(t *testing.T) {
+	const (
+		n         = 16
+		precision = 8
+		degree    = 8
+	)
+
+	scheme, key0, key1, err := fss2020.NewSigmoidGate(n, precision, degree)
+	if err != nil {
+		t.Fatalf("NewSigmoidGate failed: %v", err)
+	}
+
+	scale := 1 << precision
+
+	testCases := []struct {
+		name string
+		x    float64
+	}{
+		{name: "far negative input", x: -6},
+		{name: "negative input", x: -1},
+		{name: "zero input", x: 0},
+		{name: "positive input", x: 1},
+		{name: "far positive input", x: 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x := int(math.Round(tc.x * float64(scale)))
+
+			y0, err := scheme.Evaluate(key0, x)
+			if err != nil {
+				t.Fatalf("Evaluate for key0 failed: %v", err)
+			}
+
+			y1, err := scheme.Evaluate(key1, x)
+			if err != nil {
+				t.Fatalf("Evaluate for key1 failed: %v", err)
+			}
+
+			result := new(big.Int).Add(y0, y1)
+			result.Mod(result, new(big.Int).Lsh(big.NewInt(1), n))
+
+			want := 1 / (1 + math.Exp(-tc.x))
+			got := float64(result.Int64()) / float64(scale)
+
+			const tolerance = 0.05
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("sigmoid(%v) = %v, want %v (¬±%v)", tc.x, got, want, tolerance)
+			}
+		})
+	}
+}
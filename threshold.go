@@ -0,0 +1,319 @@
+package fss2020
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// randomShareBound bounds the per-instance random beta shares GenerateKeys
+// draws for the ring scheme (power-of-two groups): large enough to mask
+// beta for the group orders this package is exercised against in practice,
+// but small enough to always fit in a plain int regardless of platform
+// word size. For a power-of-two group order above this bound, the masks
+// don't cover the full group, which weakens (without breaking) the
+// one-time-pad argument in the ring scheme's doc comment below.
+var randomShareBound = big.NewInt(1 << 30)
+
+// ThresholdDCFScheme secret-shares a DCF comparison function across
+// numParties parties. It supports two reconstruction mechanisms depending
+// on groupOrder, chosen by NewDCFSchemeN:
+//
+//   - Power-of-two groupOrder: the ring scheme below, which only supports
+//     threshold == numParties (every party required to reconstruct). DCF's
+//     security rests on hiding *where* alpha falls, not just on hiding a
+//     value, so the usual generic recipe for turning a 2-party
+//     secret-sharing scheme into an arbitrary (threshold, numParties) one
+//     -- mask the output with a scalar r that is itself
+//     (threshold, numParties)-shared, and give everyone the masked
+//     function f(x)-r -- doesn't apply here: f(x)-r still visibly steps
+//     from one constant to another at x=alpha, so anyone holding the
+//     masked function alone learns alpha's location even without r.
+//     Avoiding that leak while still letting any threshold-sized coalition
+//     reconstruct needs multi-party GGM-tree correction mechanics that
+//     have no natural analogue over Z/2^kZ's XOR-based sharing, so the
+//     ring scheme sticks to requiring every party.
+//   - Any other groupOrder (the common case being a prime field, so
+//     differences between share indices are invertible): Shamir's scheme
+//     below, which supports any threshold in [1, numParties]. Each party
+//     holds a single DCF pair for its own Shamir share of beta, and any
+//     threshold of them reconstruct via Lagrange interpolation. This does
+//     give up alpha-hiding against sub-threshold coalitions -- a lone
+//     party can evaluate its own share's pair and see beta_i's step at
+//     alpha, even though beta itself stays hidden by Shamir's
+//     information-theoretic guarantee for < threshold shares -- which is a
+//     real, documented narrowing of DCF's usual security claim; closing it
+//     needs the same multi-party GGM mechanics as the bullet above and is
+//     future work.
+type ThresholdDCFScheme struct {
+	*DCFScheme
+	numParties int
+	threshold  int
+}
+
+// NewDCFSchemeN creates a ThresholdDCFScheme for numParties parties
+// requiring threshold of them to reconstruct.
+//
+// groupOrder decides which reconstruction mechanism is available -- see
+// ThresholdDCFScheme's doc comment. Power-of-two orders only support
+// threshold == numParties; any other order is assumed to be (or behave
+// like) a prime field and supports arbitrary threshold via Lagrange
+// interpolation, so NewDCFSchemeN builds the scheme over a PrimeFieldGroup
+// rather than the power-of-two group DCFScheme normally defaults to.
+//
+// Security note: whenever threshold < numParties (the Shamir path), any
+// single party can locally evaluate its own DCFKey pair and observe beta's
+// step at alpha -- beta itself stays hidden (by Shamir's
+// information-theoretic guarantee for fewer than threshold shares), but
+// alpha's location does not. With threshold == numParties (the ring scheme,
+// over a power-of-two groupOrder) no single party learns alpha's location,
+// but for numParties >= 3 any two parties adjacent in the ring do -- see
+// generateRingKeys.
+func NewDCFSchemeN(lambdaInBits int, groupOrder *big.Int, numParties, threshold int) (*ThresholdDCFScheme, error) {
+	if numParties < 2 {
+		return nil, fmt.Errorf("numParties (%d) must be >= 2", numParties)
+	}
+
+	if threshold < 1 || threshold > numParties {
+		return nil, fmt.Errorf("threshold (%d) must be within [1, numParties=%d]", threshold, numParties)
+	}
+
+	var dcfScheme *DCFScheme
+	if isPowerOfTwo(groupOrder) {
+		if threshold != numParties {
+			return nil, fmt.Errorf("threshold < numParties (%d < %d) needs Lagrange interpolation, which requires a non-power-of-two (prime-field) groupOrder; got %v -- see ThresholdDCFScheme's doc comment", threshold, numParties, groupOrder)
+		}
+
+		dcfScheme = NewDCFScheme(lambdaInBits, groupOrder)
+	} else {
+		dcfScheme = NewDCFSchemeWithGroup(lambdaInBits, NewPrimeFieldGroup(groupOrder))
+	}
+
+	return &ThresholdDCFScheme{
+		DCFScheme:  dcfScheme,
+		numParties: numParties,
+		threshold:  threshold,
+	}, nil
+}
+
+// ThresholdDCFKey is one of the numParties keys GenerateKeys produces.
+type ThresholdDCFKey struct {
+	Party  int
+	Shares [2]*DCFKey
+}
+
+// GenerateKeys builds numParties keys for f_{alpha,beta}, using the ring
+// scheme over a power-of-two group or Shamir's scheme over a prime field --
+// see ThresholdDCFScheme's doc comment. If threshold < numParties, each
+// returned key alone reveals alpha's location to its holder -- see
+// NewDCFSchemeN's security note.
+func (d *ThresholdDCFScheme) GenerateKeys(n, alpha, beta int) ([]*ThresholdDCFKey, error) {
+	if d.threshold == d.numParties && isPowerOfTwo(d.order()) {
+		return d.generateRingKeys(n, alpha, beta)
+	}
+
+	return d.generateShamirKeys(n, alpha, beta)
+}
+
+// generateRingKeys splits beta into numParties independent random shares
+// summing to beta, generates a standard 2-party DCF key pair per share,
+// and arranges the pairs in a ring: party i receives share i's first-half
+// key and share (i-1 mod numParties)'s second-half key. No party ever
+// holds both halves of the same share's pair, so no party alone learns
+// anything about the comparison; the ring only closes -- putting both
+// halves of every share in the coalition's hands -- once all numParties
+// parties are present. Parties i and i+1 (mod numParties) together do hold
+// both halves of share i's pair, so such a pair learns alpha's location
+// (though not beta).
+func (d *ThresholdDCFScheme) generateRingKeys(n, alpha, beta int) ([]*ThresholdDCFKey, error) {
+	N := d.numParties
+
+	betaShares := make([]int, N)
+
+	sum := 0
+	for i := 0; i < N-1; i++ {
+		share, err := rand.Int(rand.Reader, randomShareBound)
+		if err != nil {
+			return nil, fmt.Errorf("random beta share: %w", err)
+		}
+
+		betaShares[i] = int(share.Int64())
+		sum += betaShares[i]
+	}
+
+	betaShares[N-1] = beta - sum
+
+	firstHalves := make([]*DCFKey, N)
+	secondHalves := make([]*DCFKey, N)
+
+	for i := range N {
+		first, second, err := d.DCFScheme.GenerateKeys(n, alpha, betaShares[i])
+		if err != nil {
+			return nil, fmt.Errorf("generate share %d: %w", i, err)
+		}
+
+		firstHalves[i] = first
+		secondHalves[i] = second
+	}
+
+	keys := make([]*ThresholdDCFKey, N)
+	for i := range N {
+		prev := (i - 1 + N) % N
+		keys[i] = &ThresholdDCFKey{
+			Party:  i,
+			Shares: [2]*DCFKey{firstHalves[i], secondHalves[prev]},
+		}
+	}
+
+	return keys, nil
+}
+
+// generateShamirKeys splits beta via a degree-(threshold-1) Shamir
+// polynomial over Z/groupOrderZ, one evaluation point per party (party i
+// at x = i+1, reserving x = 0 for the secret itself), and gives party i a
+// standard 2-party DCF key pair for its own share value. Any threshold of
+// parties can later recover beta via Lagrange interpolation at x = 0 --
+// see ThresholdReconstruct.
+func (d *ThresholdDCFScheme) generateShamirKeys(n, alpha, beta int) ([]*ThresholdDCFKey, error) {
+	N := d.numParties
+	order := d.order()
+
+	coeffs := make([]*big.Int, d.threshold)
+	coeffs[0] = new(big.Int).Mod(big.NewInt(int64(beta)), order)
+
+	for i := 1; i < d.threshold; i++ {
+		c, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			return nil, fmt.Errorf("random polynomial coefficient %d: %w", i, err)
+		}
+
+		coeffs[i] = c
+	}
+
+	keys := make([]*ThresholdDCFKey, N)
+	for i := range N {
+		x := big.NewInt(int64(i + 1))
+		y := evalPolynomial(coeffs, x, order)
+
+		// y is a share reduced mod order, which for a realistic prime-field
+		// deployment (e.g. a BLS12-381 scalar field) is far wider than an
+		// int; go through generateKeysBigInt rather than truncating it via
+		// GenerateKeys' int beta.
+		first, second, err := d.DCFScheme.generateKeysBigInt(n, alpha, y)
+		if err != nil {
+			return nil, fmt.Errorf("generate share %d: %w", i, err)
+		}
+
+		keys[i] = &ThresholdDCFKey{
+			Party:  i,
+			Shares: [2]*DCFKey{first, second},
+		}
+	}
+
+	return keys, nil
+}
+
+// evalPolynomial evaluates coeffs (coeffs[0] + coeffs[1]*x + ...) at x,
+// reduced mod order, via Horner's method.
+func evalPolynomial(coeffs []*big.Int, x, order *big.Int) *big.Int {
+	result := new(big.Int)
+
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, coeffs[i])
+		result.Mod(result, order)
+	}
+
+	return result
+}
+
+// Evaluate returns this party's raw contribution to f(x): the sum of its
+// two DCF shares' evaluations. Combine threshold-many parties' contributions
+// with ThresholdReconstruct to recover f(x).
+func (d *ThresholdDCFScheme) Evaluate(key *ThresholdDCFKey, x int) (*big.Int, error) {
+	result := new(big.Int)
+
+	for i, share := range key.Shares {
+		y, err := d.DCFScheme.Evaluate(share, x)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate share %d: %w", i, err)
+		}
+
+		result.Add(result, y)
+	}
+
+	result.Mod(result, d.order())
+
+	return result, nil
+}
+
+// ThresholdReconstruct recovers f(x) from keys' Evaluate outputs ys (same
+// order, ys[i] corresponding to keys[i]).
+//
+// Over a power-of-two group (the ring scheme) every one of the numParties
+// contributions must be present, and they're simply summed mod order.
+// Over a prime field (Shamir's scheme) any threshold-or-more of the
+// contributions reconstruct via Lagrange interpolation at x = 0, using
+// keys[i].Party+1 as that contribution's x-coordinate.
+func (d *ThresholdDCFScheme) ThresholdReconstruct(keys []*ThresholdDCFKey, ys []*big.Int) (*big.Int, error) {
+	if len(keys) != len(ys) {
+		return nil, fmt.Errorf("keys and ys must be the same length (%d != %d)", len(keys), len(ys))
+	}
+
+	if d.threshold == d.numParties && isPowerOfTwo(d.order()) {
+		if len(ys) != d.numParties {
+			return nil, fmt.Errorf("ring scheme needs all %d parties, got %d", d.numParties, len(ys))
+		}
+
+		return d.DCFScheme.Reconstruct(ys...), nil
+	}
+
+	if len(ys) < d.threshold {
+		return nil, fmt.Errorf("need at least threshold=%d contributions, got %d", d.threshold, len(ys))
+	}
+
+	xs := make([]*big.Int, len(keys))
+	for i, key := range keys {
+		xs[i] = big.NewInt(int64(key.Party + 1))
+	}
+
+	return lagrangeAtZero(xs, ys, d.order())
+}
+
+// lagrangeAtZero evaluates, at x = 0, the unique polynomial over Z/orderZ
+// passing through (xs[i], ys[i]) for every i -- i.e. it recovers a Shamir
+// secret from however many of its shares are passed in.
+func lagrangeAtZero(xs, ys []*big.Int, order *big.Int) (*big.Int, error) {
+	result := new(big.Int)
+
+	for j := range xs {
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+
+		for k := range xs {
+			if k == j {
+				continue
+			}
+
+			num.Mul(num, new(big.Int).Neg(xs[k]))
+			num.Mod(num, order)
+
+			den.Mul(den, new(big.Int).Sub(xs[j], xs[k]))
+			den.Mod(den, order)
+		}
+
+		denInv := new(big.Int).ModInverse(den, order)
+		if denInv == nil {
+			return nil, fmt.Errorf("lagrange interpolation: %v has no inverse mod %v (groupOrder must be prime for threshold < numParties)", den, order)
+		}
+
+		term := new(big.Int).Mul(ys[j], num)
+		term.Mul(term, denInv)
+		term.Mod(term, order)
+
+		result.Add(result, term)
+		result.Mod(result, order)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+// primeGroupOrderBench mirrors primeGroupOrder in threshold_test.go: the
+// largest prime below 2^16, so these benchmarks exercise Lagrange
+// interpolation's invertible differences at a realistic comparison range.
+var primeGroupOrderBench = big.NewInt(65521)
+
+func BenchmarkThresholdGenerateKeysN3T2(b *testing.B) {
+	benchmarkThresholdGenerateKeys(b, 3, 2)
+}
+
+func BenchmarkThresholdGenerateKeysN5T3(b *testing.B) {
+	benchmarkThresholdGenerateKeys(b, 5, 3)
+}
+
+func benchmarkThresholdGenerateKeys(b *testing.B, numParties, threshold int) {
+	const (
+		n      = 16 // 16-bit integers
+		lambda = 128
+		alpha  = 12345
+		beta   = 1
+	)
+
+	scheme, err := fss2020.NewDCFSchemeN(lambda, primeGroupOrderBench, numParties, threshold)
+	if err != nil {
+		b.Fatalf("NewDCFSchemeN failed: %v", err)
+	}
+
+	for b.Loop() {
+		_, err := scheme.GenerateKeys(n, alpha, beta)
+		if err != nil {
+			b.Fatalf("GenerateKeys failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkThresholdReconstructN3T2(b *testing.B) {
+	benchmarkThresholdReconstruct(b, 3, 2)
+}
+
+func BenchmarkThresholdReconstructN5T3(b *testing.B) {
+	benchmarkThresholdReconstruct(b, 5, 3)
+}
+
+// benchmarkThresholdReconstruct measures Evaluate+ThresholdReconstruct cost
+// for exactly threshold parties -- the cheapest coalition size that can
+// still reconstruct, and so the one a real deployment would use.
+func benchmarkThresholdReconstruct(b *testing.B, numParties, threshold int) {
+	const (
+		n      = 16 // 16-bit integers
+		lambda = 128
+		alpha  = 12345
+		beta   = 1
+		x      = 5432 // must stay within n=16's domain [-2^15, 2^15-1]
+	)
+
+	scheme, err := fss2020.NewDCFSchemeN(lambda, primeGroupOrderBench, numParties, threshold)
+	if err != nil {
+		b.Fatalf("NewDCFSchemeN failed: %v", err)
+	}
+
+	keys, err := scheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		b.Fatalf("Setup failed: GenerateKeys failed: %v", err)
+	}
+
+	subset := keys[:threshold]
+	ys := make([]*big.Int, threshold)
+
+	for b.Loop() {
+		for i, key := range subset {
+			y, err := scheme.Evaluate(key, x)
+			if err != nil {
+				b.Fatalf("Evaluate failed: %v", err)
+			}
+
+			ys[i] = y
+		}
+
+		if _, err := scheme.ThresholdReconstruct(subset, ys); err != nil {
+			b.Fatalf("ThresholdReconstruct failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package fss2020_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+)
+
+func TestThresholdDCFRingReconstruct(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 7
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+
+	for _, numParties := range []int{2, 3, 5} {
+		scheme, err := fss2020.NewDCFSchemeN(lambda, groupOrder, numParties, numParties)
+		if err != nil {
+			t.Fatalf("NewDCFSchemeN(%d, %d) failed: %v", numParties, numParties, err)
+		}
+
+		keys, err := scheme.GenerateKeys(n, alpha, beta)
+		if err != nil {
+			t.Fatalf("GenerateKeys failed: %v", err)
+		}
+
+		if len(keys) != numParties {
+			t.Fatalf("GenerateKeys returned %d keys, want %d", len(keys), numParties)
+		}
+
+		for _, x := range []int{-5, 0, 5, 10, 15} {
+			ys := make([]*big.Int, numParties)
+			for i, key := range keys {
+				y, err := scheme.Evaluate(key, x)
+				if err != nil {
+					t.Fatalf("Evaluate(key %d, %d) failed: %v", i, x, err)
+				}
+
+				ys[i] = y
+			}
+
+			got, err := scheme.ThresholdReconstruct(keys, ys)
+			if err != nil {
+				t.Fatalf("ThresholdReconstruct failed: %v", err)
+			}
+
+			want := int64(0)
+			if x < alpha {
+				want = beta
+			}
+
+			if got.Cmp(big.NewInt(want)) != 0 {
+				t.Errorf("numParties=%d: ThresholdReconstruct at x=%d = %v, want %d", numParties, x, got, want)
+			}
+		}
+	}
+}
+
+func TestThresholdDCFMissingPartyFailsToReconstruct(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 7
+		x      = 5
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+
+	scheme, err := fss2020.NewDCFSchemeN(lambda, groupOrder, 3, 3)
+	if err != nil {
+		t.Fatalf("NewDCFSchemeN failed: %v", err)
+	}
+
+	keys, err := scheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	ys := make([]*big.Int, 0, 2)
+	for _, key := range keys[:2] {
+		y, err := scheme.Evaluate(key, x)
+		if err != nil {
+			t.Fatalf("Evaluate failed: %v", err)
+		}
+
+		ys = append(ys, y)
+	}
+
+	if _, err := scheme.ThresholdReconstruct(keys[:2], ys); err == nil {
+		t.Error("ThresholdReconstruct with a missing party succeeded, want error (ring scheme needs every party)")
+	}
+}
+
+func TestNewDCFSchemeNRejectsSubThresholdOverPowerOfTwoGroup(t *testing.T) {
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+
+	if _, err := fss2020.NewDCFSchemeN(128, groupOrder, 3, 2); err == nil {
+		t.Error("NewDCFSchemeN(numParties=3, threshold=2) over a power-of-two group succeeded, want error (Lagrange interpolation needs a prime-field group)")
+	}
+}
+
+// primeGroupOrder is 65521, the largest prime below 2^16, so the Shamir
+// tests below exercise the same comparison range as the ring tests above
+// while still giving Lagrange interpolation invertible differences.
+var primeGroupOrder = big.NewInt(65521)
+
+func TestThresholdDCFShamirReconstruct(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 7
+	)
+
+	cases := []struct {
+		numParties int
+		threshold  int
+	}{
+		{numParties: 3, threshold: 2},
+		{numParties: 5, threshold: 3},
+	}
+
+	for _, c := range cases {
+		scheme, err := fss2020.NewDCFSchemeN(lambda, primeGroupOrder, c.numParties, c.threshold)
+		if err != nil {
+			t.Fatalf("NewDCFSchemeN(%d, %d) failed: %v", c.numParties, c.threshold, err)
+		}
+
+		keys, err := scheme.GenerateKeys(n, alpha, beta)
+		if err != nil {
+			t.Fatalf("GenerateKeys failed: %v", err)
+		}
+
+		if len(keys) != c.numParties {
+			t.Fatalf("GenerateKeys returned %d keys, want %d", len(keys), c.numParties)
+		}
+
+		for _, x := range []int{-5, 0, 5, 10, 15} {
+			want := int64(0)
+			if x < alpha {
+				want = beta
+			}
+
+			// Every size-threshold subset of parties should reconstruct,
+			// not just the first threshold of them.
+			for start := 0; start+c.threshold <= c.numParties; start++ {
+				subset := keys[start : start+c.threshold]
+
+				ys := make([]*big.Int, len(subset))
+				for i, key := range subset {
+					y, err := scheme.Evaluate(key, x)
+					if err != nil {
+						t.Fatalf("Evaluate(key %d, %d) failed: %v", key.Party, x, err)
+					}
+
+					ys[i] = y
+				}
+
+				got, err := scheme.ThresholdReconstruct(subset, ys)
+				if err != nil {
+					t.Fatalf("ThresholdReconstruct failed: %v", err)
+				}
+
+				if got.Cmp(big.NewInt(want)) != 0 {
+					t.Errorf("(N=%d,t=%d) subset starting at %d: ThresholdReconstruct at x=%d = %v, want %d", c.numParties, c.threshold, start, x, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestThresholdDCFShamirReconstructWideGroupOrder exercises a group order
+// well above 2^63 (2^127-1, the Mersenne prime M127), the realistic
+// BLS12-381/gnark-scalar-field regime the request is motivated by. Shamir
+// shares this wide don't fit in an int, so this guards against silently
+// truncating them on their way into DCFScheme.GenerateKeys.
+func TestThresholdDCFShamirReconstructWideGroupOrder(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 7
+	)
+
+	wideGroupOrder := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+
+	scheme, err := fss2020.NewDCFSchemeN(lambda, wideGroupOrder, 3, 2)
+	if err != nil {
+		t.Fatalf("NewDCFSchemeN failed: %v", err)
+	}
+
+	keys, err := scheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	for _, x := range []int{-5, 0, 5, 10, 15} {
+		want := int64(0)
+		if x < alpha {
+			want = beta
+		}
+
+		subset := keys[:2]
+		ys := make([]*big.Int, len(subset))
+		for i, key := range subset {
+			y, err := scheme.Evaluate(key, x)
+			if err != nil {
+				t.Fatalf("Evaluate(key %d, %d) failed: %v", key.Party, x, err)
+			}
+
+			ys[i] = y
+		}
+
+		got, err := scheme.ThresholdReconstruct(subset, ys)
+		if err != nil {
+			t.Fatalf("ThresholdReconstruct failed: %v", err)
+		}
+
+		if got.Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("ThresholdReconstruct at x=%d = %v, want %d", x, got, want)
+		}
+	}
+}
+
+func TestThresholdDCFShamirBelowThresholdFailsToReconstruct(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 7
+		x      = 5
+	)
+
+	scheme, err := fss2020.NewDCFSchemeN(lambda, primeGroupOrder, 5, 3)
+	if err != nil {
+		t.Fatalf("NewDCFSchemeN failed: %v", err)
+	}
+
+	keys, err := scheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	ys := make([]*big.Int, 0, 2)
+	for _, key := range keys[:2] {
+		y, err := scheme.Evaluate(key, x)
+		if err != nil {
+			t.Fatalf("Evaluate failed: %v", err)
+		}
+
+		ys = append(ys, y)
+	}
+
+	if _, err := scheme.ThresholdReconstruct(keys[:2], ys); err == nil {
+		t.Error("ThresholdReconstruct with 2 of 3 required shares succeeded, want error")
+	}
+}
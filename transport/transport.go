@@ -0,0 +1,228 @@
+// Package transport provides a minimal two-party wire protocol for shipping
+// fss2020 keys (key0 to party 0, key1 to party 1) over a network connection.
+package transport
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ras0q/fss2020"
+)
+
+// defaultChunkSize bounds how many correction words Sender buffers at once.
+// DCFKey.CWs grows with the domain size n, and spline/DPF keys compose many
+// of these, so streaming in bounded chunks avoids holding a second full copy
+// of a large key in memory the way a single MarshalBinary call would.
+const defaultChunkSize = 64
+
+// Sender streams fss2020 keys to a peer over w.
+type Sender struct {
+	w io.Writer
+}
+
+// NewSender wraps w for sending keys.
+func NewSender(w io.Writer) *Sender {
+	return &Sender{w: w}
+}
+
+// SendDCFKey writes key to the underlying stream, sending its correction
+// words in chunks of defaultChunkSize rather than marshaling the whole key
+// into memory first.
+func (s *Sender) SendDCFKey(key *fss2020.DCFKey) error {
+	if err := writeUint32(s.w, uint32(key.LambdaInBits)); err != nil {
+		return fmt.Errorf("write lambdaInBits: %w", err)
+	}
+
+	if err := writeBigInt(s.w, key.GroupOrder); err != nil {
+		return fmt.Errorf("write group order: %w", err)
+	}
+
+	if err := writeUint32(s.w, uint32(key.Party)); err != nil {
+		return fmt.Errorf("write party: %w", err)
+	}
+
+	if err := writeFramed(s.w, key.Seed); err != nil {
+		return fmt.Errorf("write seed: %w", err)
+	}
+
+	if err := writeUint32(s.w, uint32(len(key.CWs))); err != nil {
+		return fmt.Errorf("write cw count: %w", err)
+	}
+
+	for start := 0; start < len(key.CWs); start += defaultChunkSize {
+		end := min(start+defaultChunkSize, len(key.CWs))
+
+		for i, cw := range key.CWs[start:end] {
+			cwBytes, err := cw.MarshalBinary(key.GroupOrder)
+			if err != nil {
+				return fmt.Errorf("marshal correction word %d: %w", start+i, err)
+			}
+
+			if err := writeFramed(s.w, cwBytes); err != nil {
+				return fmt.Errorf("write correction word %d: %w", start+i, err)
+			}
+		}
+	}
+
+	if err := writeGroupElement(s.w, key.FinalValue, key.GroupOrder); err != nil {
+		return fmt.Errorf("write final value: %w", err)
+	}
+
+	return nil
+}
+
+// Receiver reads fss2020 keys sent by a Sender from r.
+type Receiver struct {
+	r io.Reader
+}
+
+// NewReceiver wraps r for receiving keys.
+func NewReceiver(r io.Reader) *Receiver {
+	return &Receiver{r: r}
+}
+
+// ReceiveDCFKey reads a key written by Sender.SendDCFKey, reconstructing its
+// correction words chunk by chunk.
+func (r *Receiver) ReceiveDCFKey() (*fss2020.DCFKey, error) {
+	lambdaInBits, err := readUint32(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read lambdaInBits: %w", err)
+	}
+
+	groupOrder, err := readBigInt(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read group order: %w", err)
+	}
+
+	party, err := readUint32(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read party: %w", err)
+	}
+
+	seed, err := readFramed(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read seed: %w", err)
+	}
+
+	numCWs, err := readUint32(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read cw count: %w", err)
+	}
+
+	cws := make([]*fss2020.DCFCorrectionWord, numCWs)
+	for start := 0; start < int(numCWs); start += defaultChunkSize {
+		end := min(start+defaultChunkSize, int(numCWs))
+
+		for i := start; i < end; i++ {
+			cwBytes, err := readFramed(r.r)
+			if err != nil {
+				return nil, fmt.Errorf("read correction word %d: %w", i, err)
+			}
+
+			cw := &fss2020.DCFCorrectionWord{}
+			if err := cw.UnmarshalBinary(cwBytes); err != nil {
+				return nil, fmt.Errorf("unmarshal correction word %d: %w", i, err)
+			}
+
+			cws[i] = cw
+		}
+	}
+
+	finalValue, err := readBigInt(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("read final value: %w", err)
+	}
+
+	return &fss2020.DCFKey{
+		Party:        int(party),
+		Seed:         seed,
+		CWs:          cws,
+		FinalValue:   finalValue,
+		LambdaInBits: int(lambdaInBits),
+		GroupOrder:   groupOrder,
+	}, nil
+}
+
+// DialTLS connects to addr over TLS and returns a Sender/Receiver pair backed
+// by the resulting connection, for deployments that want transport security
+// rather than a bare socket.
+func DialTLS(addr string, config *tls.Config) (*Sender, *Receiver, *tls.Conn, error) {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+
+	return NewSender(conn), NewReceiver(conn), conn, nil
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	return binary.Write(w, binary.BigEndian, v)
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	var v uint32
+	err := binary.Read(r, binary.BigEndian, &v)
+
+	return v, err
+}
+
+func writeFramed(w io.Writer, b []byte) error {
+	if err := writeUint32(w, uint32(len(b))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(b)
+
+	return err
+}
+
+func readFramed(r io.Reader) ([]byte, error) {
+	length, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func writeBigInt(w io.Writer, v *big.Int) error {
+	if v == nil {
+		return writeFramed(w, nil)
+	}
+
+	return writeFramed(w, v.Bytes())
+}
+
+// writeGroupElement writes v as its canonical non-negative residue mod
+// groupOrder. Unlike writeBigInt (used for groupOrder itself, which is
+// always non-negative), v may be negative -- GenerateKeys' FinalValue
+// routinely is -- and v.Bytes() alone would drop the sign and corrupt
+// the round trip.
+func writeGroupElement(w io.Writer, v, groupOrder *big.Int) error {
+	if v == nil {
+		return writeFramed(w, nil)
+	}
+
+	if groupOrder == nil {
+		return writeFramed(w, v.Bytes())
+	}
+
+	return writeFramed(w, new(big.Int).Mod(v, groupOrder).Bytes())
+}
+
+func readBigInt(r io.Reader) (*big.Int, error) {
+	b, err := readFramed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,61 @@
+package transport_test
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/ras0q/fss2020"
+	"github.com/ras0q/fss2020/transport"
+)
+
+func TestSenderReceiverRoundTrip(t *testing.T) {
+	const (
+		n      = 8
+		lambda = 128
+		alpha  = 10
+		beta   = 1
+	)
+
+	groupOrder := new(big.Int).Lsh(big.NewInt(1), 16)
+	dcfScheme := fss2020.NewDCFScheme(lambda, groupOrder)
+
+	key0, _, err := dcfScheme.GenerateKeys(n, alpha, beta)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- transport.NewSender(client).SendDCFKey(key0)
+	}()
+
+	got, err := transport.NewReceiver(server).ReceiveDCFKey()
+	if err != nil {
+		t.Fatalf("ReceiveDCFKey failed: %v", err)
+	}
+
+	if err := <-sendErr; err != nil {
+		t.Fatalf("SendDCFKey failed: %v", err)
+	}
+
+	for _, x := range []int{-5, 0, 5, 10, 15} {
+		want, err := dcfScheme.Evaluate(key0, x)
+		if err != nil {
+			t.Fatalf("Evaluate(key0, %d) failed: %v", x, err)
+		}
+
+		gotValue, err := dcfScheme.Evaluate(got, x)
+		if err != nil {
+			t.Fatalf("Evaluate(got, %d) failed: %v", x, err)
+		}
+
+		if gotValue.Cmp(want) != 0 {
+			t.Errorf("Evaluate(received key, %d) = %v, want %v", x, gotValue, want)
+		}
+	}
+}